@@ -7,9 +7,11 @@ package app
 import (
 	"context"
 	"errors"
-	"net"
 	"os"
 
+	configv1alpha1 "github.com/gardener/gardener-metrics-exporter/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-metrics-exporter/pkg/health"
+	"github.com/gardener/gardener-metrics-exporter/pkg/leaderelection"
 	"github.com/gardener/gardener-metrics-exporter/pkg/metrics"
 	"github.com/gardener/gardener-metrics-exporter/pkg/server"
 	"github.com/gardener/gardener-metrics-exporter/pkg/version"
@@ -20,44 +22,16 @@ import (
 	seedmanagementclientset "github.com/gardener/gardener/pkg/client/seedmanagement/clientset/versioned"
 	gardenseedmanagementinformers "github.com/gardener/gardener/pkg/client/seedmanagement/informers/externalversions"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 var log *logrus.Logger
 
-type options struct {
-	bindAddress    string
-	port           int
-	kubeconfigPath string
-}
-
-func (o *options) validate() bool {
-	// Validate whether a kubeconfig file exists under the given path.
-	if o.kubeconfigPath != "" {
-		if _, err := os.Stat(o.kubeconfigPath); os.IsNotExist(err) {
-			log.Errorf("kubeconfig does not exist on path %s", o.kubeconfigPath)
-			return false
-		}
-	}
-
-	// Validate whether the passed IP address is valid.
-	if ip := net.ParseIP(o.bindAddress); ip == nil {
-		log.Errorf("bind-address is not a valid ip %s", o.bindAddress)
-		return false
-	}
-
-	// Validate if the port is in range.
-	if o.port < 0 || o.port > 65535 {
-		log.Errorf("port is out of range: %d", o.port)
-		return false
-	}
-	return true
-}
-
 // NewStartGardenMetricsExporter creates a new GardenMetricsExporter command.
 func NewStartGardenMetricsExporter(ctx context.Context, logger *logrus.Logger) *cobra.Command {
 	log = logger
@@ -66,78 +40,164 @@ func NewStartGardenMetricsExporter(ctx context.Context, logger *logrus.Logger) *
 		Use:  "gardener-metrics-exporter",
 		Long: "A Prometheus exporter for Gardener related metrics.",
 		Run: func(cmd *cobra.Command, args []string) {
-			if !options.validate() {
+			if err := options.complete(cmd.Flags().Changed); err != nil {
+				log.Error(err.Error())
+				os.Exit(1)
+			}
+			if err := options.validate(); err != nil {
+				log.Errorf("Invalid configuration: %s", err.Error())
 				os.Exit(1)
 			}
-			if err := run(ctx, &options); err != nil {
+			if err := applyLoggingConfiguration(log, options.config.Logging); err != nil {
+				log.Error(err.Error())
+				os.Exit(1)
+			}
+			if err := run(ctx, options.config); err != nil {
 				log.Error(err.Error())
 				os.Exit(1)
 			}
 		},
 	}
 	cmd.AddCommand(version.GetVersionCmd())
-	cmd.Flags().StringVar(&options.bindAddress, "bind-address", "0.0.0.0", "bind address for the webserver")
-	cmd.Flags().IntVar(&options.port, "port", 2718, "port for the webserver")
-	cmd.Flags().StringVar(&options.kubeconfigPath, "kubeconfig", "", "path to kubeconfig file for a Garden cluster")
+	options.addFlags(cmd)
 	return cmd
 }
 
-func run(ctx context.Context, o *options) error {
+func run(ctx context.Context, config *configv1alpha1.MetricsExporterConfiguration) error {
 	stopCh := make(chan struct{})
 
-	// Create informer factories to create informers.
-	gardenInformerFactory, gardenSeedManagementInformerFactory, gardenSecurityInformerFactory, err := setupInformerFactories(o.kubeconfigPath)
+	// The exporter is ready once the informers of all Garden clusters have synced, and live as long as the
+	// snapshots of the Garden clusters are refreshed.
+	healthTracker := health.NewTracker(config.Server.Readiness.WatchFailureThreshold.Duration)
+
+	// Create informer factories to create informers for every Garden cluster.
+	gardens, err := setupInformerFactories(gardenConfigurations(config), config.KubeClient, healthTracker)
 	if err != nil {
 		return err
 	}
 
-	// Create informers.
-	var (
-		managedSeedInformer        = gardenSeedManagementInformerFactory.Seedmanagement().V1alpha1().ManagedSeeds().Informer()
-		gardenletInformer          = gardenSeedManagementInformerFactory.Seedmanagement().V1alpha1().Gardenlets().Informer()
-		shootInformer              = gardenInformerFactory.Core().V1beta1().Shoots().Informer()
-		seedInformer               = gardenInformerFactory.Core().V1beta1().Seeds().Informer()
-		projectInformer            = gardenInformerFactory.Core().V1beta1().Projects().Informer()
-		secretBindingInformer      = gardenInformerFactory.Core().V1beta1().SecretBindings().Informer()
-		credentialsBindingInformer = gardenSecurityInformerFactory.Security().V1alpha1().CredentialsBindings().Informer()
-	)
+	metrics.RegisterExporterMetrics()
+	for _, garden := range gardens {
+		// Setup the metrics collector. It creates the informers for the enabled collectors.
+		collector, err := metrics.SetupMetricsCollector(ctx, garden.landscape, garden.factories, collectorOptions(config, healthTracker), log)
+		if err != nil {
+			return err
+		}
 
-	// Start the factories and wait until the informers have synced.
-	gardenInformerFactory.Start(stopCh)
-	if !cache.WaitForCacheSync(ctx.Done(), shootInformer.HasSynced, seedInformer.HasSynced, projectInformer.HasSynced, secretBindingInformer.HasSynced) {
-		return errors.New("timed out waiting for Garden caches to sync")
-	}
+		prometheus.MustRegister(collector)
 
-	gardenSeedManagementInformerFactory.Start(stopCh)
-	if !cache.WaitForCacheSync(ctx.Done(), managedSeedInformer.HasSynced, gardenletInformer.HasSynced) {
-		return errors.New("timed out waiting for Seed Management caches to sync")
+		// Start the factories without waiting for the informers to sync. Collectors are skipped until the
+		// informers they depend on have synced, so that a slow informer or Garden cluster does not block the others.
+		garden.factories.Core.Start(stopCh)
+		garden.factories.SeedManagement.Start(stopCh)
+		garden.factories.Security.Start(stopCh)
 	}
 
-	gardenSecurityInformerFactory.Start(stopCh)
-	if !cache.WaitForCacheSync(ctx.Done(), credentialsBindingInformer.HasSynced) {
-		return errors.New("timed out waiting for Security caches to sync")
+	// Start the leader election in the first Garden cluster. Followers keep their informers warm, but do not
+	// serve the Garden metrics.
+	if config.LeaderElection.Enabled {
+		if err := startLeaderElection(ctx, gardens[0].restConfig, config.LeaderElection); err != nil {
+			return err
+		}
 	}
 
-	// Start the metrics collector
-	metrics.SetupMetricsCollector(
-		gardenInformerFactory.Core().V1beta1().Shoots(),
-		gardenInformerFactory.Core().V1beta1().Seeds(),
-		gardenInformerFactory.Core().V1beta1().Projects(),
-		gardenSeedManagementInformerFactory.Seedmanagement().V1alpha1().ManagedSeeds(),
-		gardenSeedManagementInformerFactory.Seedmanagement().V1alpha1().Gardenlets(),
-		gardenInformerFactory.Core().V1beta1().SecretBindings(),
-		gardenSecurityInformerFactory.Security().V1alpha1().CredentialsBindings(),
-		log,
-	)
-
 	// Start the webserver.
-	go server.Serve(ctx, o.bindAddress, o.port, log, stopCh)
+	serverOptions, err := newServerOptions(config.Server)
+	if err != nil {
+		return err
+	}
+	serverOptions.Readiness = healthTracker.Ready
+	serverOptions.Liveness = healthTracker.Live
+	go server.Serve(ctx, serverOptions, log, stopCh)
 
 	<-stopCh
 	log.Info("App shut down.")
 	return nil
 }
 
+// gardenConfigurations returns the configured Garden clusters. Without explicit Garden clusters, the cluster of
+// the kube client configuration is scraped without a landscape name.
+func gardenConfigurations(config *configv1alpha1.MetricsExporterConfiguration) []configv1alpha1.GardenConfiguration {
+	if len(config.Gardens) > 0 {
+		return config.Gardens
+	}
+	return []configv1alpha1.GardenConfiguration{{Kubeconfig: config.KubeClient.Kubeconfig}}
+}
+
+// collectorOptions returns the options of the metrics collectors.
+func collectorOptions(config *configv1alpha1.MetricsExporterConfiguration, healthTracker *health.Tracker) metrics.CollectorOptions {
+	return metrics.CollectorOptions{
+		Workers:            config.Collectors.Workers,
+		Timeout:            config.Collectors.Timeout.Duration,
+		RefreshInterval:    config.Collectors.RefreshInterval.Duration,
+		DisabledCollectors: config.Collectors.Disabled,
+		Metrics: metrics.MetricFilter{
+			Allow: config.Collectors.Metrics.Allow,
+			Deny:  config.Collectors.Metrics.Deny,
+		},
+		Labels: metrics.LabelOptions{
+			CostObjectAnnotation:     config.Labels.CostObjectAnnotation,
+			CostObjectTypeAnnotation: config.Labels.CostObjectTypeAnnotation,
+			ErrorCodeClasses:         config.Labels.ErrorCodeClasses,
+		},
+		Health: healthTracker,
+	}
+}
+
+// newServerOptions returns the options of the webserver. The client for the token authentication is created
+// for the cluster of the authentication kubeconfig.
+func newServerOptions(config configv1alpha1.ServerConfiguration) (server.Options, error) {
+	options := server.Options{
+		BindAddress: config.BindAddress,
+		Port:        config.Port,
+	}
+	if config.TLS != nil {
+		options.TLS = &server.TLSOptions{
+			CertFile:     config.TLS.CertFile,
+			KeyFile:      config.TLS.KeyFile,
+			ClientCAFile: config.TLS.ClientCAFile,
+		}
+	}
+	if config.Authentication.TokenReview {
+		restConfig, err := newClientConfig(config.Authentication.Kubeconfig)
+		if err != nil {
+			return options, err
+		}
+		if options.AuthClient, err = kubernetes.NewForConfig(restConfig); err != nil {
+			return options, err
+		}
+	}
+	return options, nil
+}
+
+// startLeaderElection starts the leader election based on a Lease in the Garden cluster. The exporter is a
+// follower until it acquired the Lease.
+func startLeaderElection(ctx context.Context, restConfig *rest.Config, config configv1alpha1.LeaderElectionConfiguration) error {
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	metrics.SetLeading(false)
+	go func() {
+		if err := leaderelection.Run(ctx, client, leaderelection.Config{
+			LeaseName:      config.LeaseName,
+			LeaseNamespace: config.LeaseNamespace,
+			Identity:       identity,
+			LeaseDuration:  config.LeaseDuration.Duration,
+			RenewDeadline:  config.RenewDeadline.Duration,
+			RetryPeriod:    config.RetryPeriod.Duration,
+		}, metrics.SetLeading, log); err != nil {
+			log.Errorf("Leader election failed: %s", err.Error())
+		}
+	}()
+	return nil
+}
+
 // newClientConfig returns rest config to create a k8s clients. In case that
 // kubeconfigPath is empty it tries to create in cluster configuration.
 func newClientConfig(kubeconfigPath string) (*rest.Config, error) {
@@ -170,38 +230,60 @@ func newClientConfig(kubeconfigPath string) (*rest.Config, error) {
 	return client, nil
 }
 
-func setupInformerFactories(kubeconfigPath string) (gardencoreinformers.SharedInformerFactory, gardenseedmanagementinformers.SharedInformerFactory, securityinformers.SharedInformerFactory, error) {
-	restConfig, err := newClientConfig(kubeconfigPath)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	if restConfig == nil {
-		return nil, nil, nil, err
-	}
-	gardenClient, err := clientset.NewForConfig(restConfig)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	if gardenClient == nil {
-		return nil, nil, nil, errors.New("gardenClient is nil")
-	}
-	var gardenManagedSeedClient *seedmanagementclientset.Clientset
-	if gardenManagedSeedClient, err = seedmanagementclientset.NewForConfig(restConfig); err != nil {
-		return nil, nil, nil, err
-	}
-	if gardenManagedSeedClient == nil {
-		return nil, nil, nil, errors.New("gardenManagedSeedClient is nil")
-	}
-	gardenSecurityClient, err := securityclientset.NewForConfig(restConfig)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	if gardenSecurityClient == nil {
-		return nil, nil, nil, errors.New("gardenSecurityClient is nil")
-	}
+// garden holds the clients of the Garden cluster of a landscape.
+type garden struct {
+	landscape  string
+	restConfig *rest.Config
+	factories  metrics.InformerFactories
+}
+
+// setupInformerFactories creates the informer factories for each of the given Garden clusters. Their clients report
+// successful list and watch requests to the health tracker.
+func setupInformerFactories(gardens []configv1alpha1.GardenConfiguration, kubeClient configv1alpha1.KubeClientConfiguration, healthTracker *health.Tracker) ([]garden, error) {
+	var result []garden
+	for _, gardenConfig := range gardens {
+		restConfig, err := newClientConfig(gardenConfig.Kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		if restConfig == nil {
+			return nil, errors.New("rest config is nil")
+		}
+		restConfig.QPS = kubeClient.QPS
+		restConfig.Burst = kubeClient.Burst
+		restConfig.Wrap(healthTracker.WrapTransport(gardenConfig.Name))
 
-	gardenInformerFactory := gardencoreinformers.NewSharedInformerFactory(gardenClient, 0)
-	gardenSeedManagementInformerFactory := gardenseedmanagementinformers.NewSharedInformerFactory(gardenManagedSeedClient, 0)
-	securityInformerFactory := securityinformers.NewSharedInformerFactory(gardenSecurityClient, 0)
-	return gardenInformerFactory, gardenSeedManagementInformerFactory, securityInformerFactory, nil
+		gardenClient, err := clientset.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		if gardenClient == nil {
+			return nil, errors.New("gardenClient is nil")
+		}
+		var gardenManagedSeedClient *seedmanagementclientset.Clientset
+		if gardenManagedSeedClient, err = seedmanagementclientset.NewForConfig(restConfig); err != nil {
+			return nil, err
+		}
+		if gardenManagedSeedClient == nil {
+			return nil, errors.New("gardenManagedSeedClient is nil")
+		}
+		gardenSecurityClient, err := securityclientset.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		if gardenSecurityClient == nil {
+			return nil, errors.New("gardenSecurityClient is nil")
+		}
+
+		result = append(result, garden{
+			landscape:  gardenConfig.Name,
+			restConfig: restConfig,
+			factories: metrics.InformerFactories{
+				Core:           gardencoreinformers.NewSharedInformerFactory(gardenClient, 0),
+				SeedManagement: gardenseedmanagementinformers.NewSharedInformerFactory(gardenManagedSeedClient, 0),
+				Security:       securityinformers.NewSharedInformerFactory(gardenSecurityClient, 0),
+			},
+		})
+	}
+	return result, nil
 }
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	configv1alpha1 "github.com/gardener/gardener-metrics-exporter/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-metrics-exporter/pkg/apis/config/v1alpha1/validation"
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// options holds the command line flags. Flags which are set explicitly override the values of the configuration file.
+type options struct {
+	configFile string
+
+	bindAddress      string
+	port             int
+	tlsCertFile      string
+	tlsKeyFile       string
+	tlsClientCAFile  string
+	tokenReview      bool
+	watchFailure     time.Duration
+	kubeconfigPath   string
+	gardens          map[string]string
+	collectorWorkers int
+	collectorTimeout time.Duration
+	refreshInterval  time.Duration
+
+	disabledCollectors []string
+	metricsAllowlist   []string
+	metricsDenylist    []string
+	errorCodeClasses   map[string]string
+
+	leaderElect    bool
+	leaseName      string
+	leaseNamespace string
+
+	config *configv1alpha1.MetricsExporterConfiguration
+}
+
+func (o *options) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.configFile, "config", "", "path to a MetricsExporterConfiguration file, flags override its values")
+	flags.StringVar(&o.bindAddress, "bind-address", configv1alpha1.DefaultBindAddress, "bind address for the webserver")
+	flags.IntVar(&o.port, "port", configv1alpha1.DefaultPort, "port for the webserver")
+	flags.StringVar(&o.tlsCertFile, "tls-cert-file", "", "path to the serving certificate, enables TLS")
+	flags.StringVar(&o.tlsKeyFile, "tls-private-key-file", "", "path to the private key of the serving certificate")
+	flags.StringVar(&o.tlsClientCAFile, "tls-client-ca-file", "", "path to a CA bundle, allows requests to the metrics endpoint with a client certificate signed by it")
+	flags.BoolVar(&o.tokenReview, "authentication-token-review", false, "allow requests to the metrics endpoint with a bearer token authorized by TokenReview and SubjectAccessReview")
+	flags.DurationVar(&o.watchFailure, "watch-failure-threshold", configv1alpha1.DefaultWatchFailureThreshold, "duration after which a broken informer watch makes the exporter unready")
+	flags.StringVar(&o.kubeconfigPath, "kubeconfig", "", "path to kubeconfig file for a Garden cluster")
+	flags.StringToStringVar(&o.gardens, "gardens", nil, "named kubeconfig files of several Garden clusters, e.g. dev=/path/to/dev,live=/path/to/live")
+	flags.IntVar(&o.collectorWorkers, "collector-workers", configv1alpha1.DefaultCollectorWorkers, "maximum number of metric collectors running in parallel")
+	flags.DurationVar(&o.collectorTimeout, "collector-timeout", configv1alpha1.DefaultCollectorTimeout, "maximum duration of a single metric collector, 0 disables the timeout")
+	flags.DurationVar(&o.refreshInterval, "refresh-interval", configv1alpha1.DefaultRefreshInterval, "minimum duration between two refreshes of the metrics snapshot")
+	flags.StringSliceVar(&o.disabledCollectors, "disable-collectors", nil, fmt.Sprintf("collectors which are disabled, one of: %s", strings.Join(names.CollectorNames, ", ")))
+	flags.StringSliceVar(&o.metricsAllowlist, "metrics-allowlist", nil, "names of the metrics which are exposed, all metrics are exposed if empty")
+	flags.StringSliceVar(&o.metricsDenylist, "metrics-denylist", nil, "names of the metrics which are not exposed")
+	flags.StringToStringVar(&o.errorCodeClasses, "error-code-classes", nil, fmt.Sprintf("classes of Shoot error codes which override the defaults, e.g. ERR_INFRA_RATE_LIMITS_EXCEEDED=user, one of: %s", strings.Join(names.ErrorClasses, ", ")))
+	flags.BoolVar(&o.leaderElect, "leader-elect", false, "enable leader election, only the leader serves the Garden metrics")
+	flags.StringVar(&o.leaseName, "leader-election-lease-name", configv1alpha1.DefaultLeaseName, "name of the Lease used for leader election")
+	flags.StringVar(&o.leaseNamespace, "leader-election-namespace", configv1alpha1.DefaultLeaseNamespace, "namespace of the Lease used for leader election")
+}
+
+// complete loads the configuration file, if any, applies the explicitly set flags on top of it and sets the defaults.
+func (o *options) complete(changed func(name string) bool) error {
+	o.config = &configv1alpha1.MetricsExporterConfiguration{}
+	if o.configFile != "" {
+		config, err := configv1alpha1.Load(o.configFile)
+		if err != nil {
+			return err
+		}
+		o.config = config
+	}
+
+	if changed("bind-address") {
+		o.config.Server.BindAddress = o.bindAddress
+	}
+	if changed("port") {
+		o.config.Server.Port = o.port
+	}
+	if changed("tls-cert-file") || changed("tls-private-key-file") || changed("tls-client-ca-file") {
+		if o.config.Server.TLS == nil {
+			o.config.Server.TLS = &configv1alpha1.TLSConfiguration{}
+		}
+		if changed("tls-cert-file") {
+			o.config.Server.TLS.CertFile = o.tlsCertFile
+		}
+		if changed("tls-private-key-file") {
+			o.config.Server.TLS.KeyFile = o.tlsKeyFile
+		}
+		if changed("tls-client-ca-file") {
+			o.config.Server.TLS.ClientCAFile = o.tlsClientCAFile
+		}
+	}
+	if changed("authentication-token-review") {
+		o.config.Server.Authentication.TokenReview = o.tokenReview
+	}
+	if changed("watch-failure-threshold") {
+		o.config.Server.Readiness.WatchFailureThreshold = metav1.Duration{Duration: o.watchFailure}
+	}
+	if changed("kubeconfig") {
+		o.config.KubeClient.Kubeconfig = o.kubeconfigPath
+	}
+	if changed("gardens") {
+		o.config.Gardens = nil
+		for _, name := range slices.Sorted(maps.Keys(o.gardens)) {
+			o.config.Gardens = append(o.config.Gardens, configv1alpha1.GardenConfiguration{Name: name, Kubeconfig: o.gardens[name]})
+		}
+	}
+	if changed("collector-workers") {
+		o.config.Collectors.Workers = o.collectorWorkers
+	}
+	if changed("collector-timeout") {
+		o.config.Collectors.Timeout = &metav1.Duration{Duration: o.collectorTimeout}
+	}
+	if changed("refresh-interval") {
+		o.config.Collectors.RefreshInterval = &metav1.Duration{Duration: o.refreshInterval}
+	}
+	if changed("disable-collectors") {
+		o.config.Collectors.Disabled = o.disabledCollectors
+	}
+	if changed("metrics-allowlist") {
+		o.config.Collectors.Metrics.Allow = o.metricsAllowlist
+	}
+	if changed("metrics-denylist") {
+		o.config.Collectors.Metrics.Deny = o.metricsDenylist
+	}
+	if changed("error-code-classes") {
+		o.config.Labels.ErrorCodeClasses = o.errorCodeClasses
+	}
+	if changed("leader-elect") {
+		o.config.LeaderElection.Enabled = o.leaderElect
+	}
+	if changed("leader-election-lease-name") {
+		o.config.LeaderElection.LeaseName = o.leaseName
+	}
+	if changed("leader-election-namespace") {
+		o.config.LeaderElection.LeaseNamespace = o.leaseNamespace
+	}
+
+	configv1alpha1.SetDefaults_MetricsExporterConfiguration(o.config)
+	return nil
+}
+
+// validate validates the completed configuration and reports all problems at once.
+func (o *options) validate() error {
+	return validation.ValidateMetricsExporterConfiguration(o.config).ToAggregate()
+}
+
+// applyLoggingConfiguration configures the logger according to the given configuration.
+func applyLoggingConfiguration(logger *logrus.Logger, logging configv1alpha1.LoggingConfiguration) error {
+	level, err := logrus.ParseLevel(logging.Level)
+	if err != nil {
+		return err
+	}
+	logger.SetLevel(level)
+
+	if logging.Format == configv1alpha1.LogFormatJSON {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+	return nil
+}
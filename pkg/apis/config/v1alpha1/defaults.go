@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Default values of the configuration.
+const (
+	DefaultBindAddress              = "0.0.0.0"
+	DefaultPort                     = 2718
+	DefaultWatchFailureThreshold    = 2 * time.Minute
+	DefaultQPS                      = 20
+	DefaultBurst                    = 30
+	DefaultCollectorWorkers         = 4
+	DefaultCollectorTimeout         = 20 * time.Second
+	DefaultRefreshInterval          = 10 * time.Second
+	DefaultCostObjectAnnotation     = "billing.gardener.cloud/costObject"
+	DefaultCostObjectTypeAnnotation = "billing.gardener.cloud/costObjectType"
+	DefaultLogLevel                 = "info"
+	DefaultLogFormat                = LogFormatText
+	DefaultLeaseName                = "gardener-metrics-exporter-leader-election"
+	DefaultLeaseNamespace           = "garden"
+	DefaultLeaseDuration            = 15 * time.Second
+	DefaultRenewDeadline            = 10 * time.Second
+	DefaultRetryPeriod              = 2 * time.Second
+)
+
+// SetDefaults_MetricsExporterConfiguration sets the defaults for the MetricsExporterConfiguration.
+func SetDefaults_MetricsExporterConfiguration(obj *MetricsExporterConfiguration) { // nolint:revive // defaulting functions follow the Kubernetes naming convention
+	if obj.APIVersion == "" {
+		obj.APIVersion = SchemeGroupVersion.String()
+	}
+	if obj.Kind == "" {
+		obj.Kind = Kind
+	}
+
+	if obj.Server.BindAddress == "" {
+		obj.Server.BindAddress = DefaultBindAddress
+	}
+	if obj.Server.Port == 0 {
+		obj.Server.Port = DefaultPort
+	}
+
+	if obj.Server.Readiness.WatchFailureThreshold == (metav1.Duration{}) {
+		obj.Server.Readiness.WatchFailureThreshold = metav1.Duration{Duration: DefaultWatchFailureThreshold}
+	}
+
+	if obj.KubeClient.QPS == 0 {
+		obj.KubeClient.QPS = DefaultQPS
+	}
+	if obj.KubeClient.Burst == 0 {
+		obj.KubeClient.Burst = DefaultBurst
+	}
+
+	if obj.Collectors.Workers == 0 {
+		obj.Collectors.Workers = DefaultCollectorWorkers
+	}
+	// The timeout and the refresh interval may explicitly be 0, hence only unset values are defaulted.
+	if obj.Collectors.Timeout == nil {
+		obj.Collectors.Timeout = &metav1.Duration{Duration: DefaultCollectorTimeout}
+	}
+	if obj.Collectors.RefreshInterval == nil {
+		obj.Collectors.RefreshInterval = &metav1.Duration{Duration: DefaultRefreshInterval}
+	}
+
+	if obj.Labels.CostObjectAnnotation == "" {
+		obj.Labels.CostObjectAnnotation = DefaultCostObjectAnnotation
+	}
+	if obj.Labels.CostObjectTypeAnnotation == "" {
+		obj.Labels.CostObjectTypeAnnotation = DefaultCostObjectTypeAnnotation
+	}
+
+	if obj.Logging.Level == "" {
+		obj.Logging.Level = DefaultLogLevel
+	}
+	if obj.Logging.Format == "" {
+		obj.Logging.Format = DefaultLogFormat
+	}
+
+	if obj.LeaderElection.LeaseName == "" {
+		obj.LeaderElection.LeaseName = DefaultLeaseName
+	}
+	if obj.LeaderElection.LeaseNamespace == "" {
+		obj.LeaderElection.LeaseNamespace = DefaultLeaseNamespace
+	}
+	if obj.LeaderElection.LeaseDuration == (metav1.Duration{}) {
+		obj.LeaderElection.LeaseDuration = metav1.Duration{Duration: DefaultLeaseDuration}
+	}
+	if obj.LeaderElection.RenewDeadline == (metav1.Duration{}) {
+		obj.LeaderElection.RenewDeadline = metav1.Duration{Duration: DefaultRenewDeadline}
+	}
+	if obj.LeaderElection.RetryPeriod == (metav1.Duration{}) {
+		obj.LeaderElection.RetryPeriod = metav1.Duration{Duration: DefaultRetryPeriod}
+	}
+}
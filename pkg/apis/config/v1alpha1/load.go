@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Load reads the MetricsExporterConfiguration from the given file. Unknown fields are rejected.
+// The returned configuration is not defaulted.
+func Load(path string) (*MetricsExporterConfiguration, error) {
+	data, err := os.ReadFile(path) // #nosec G304: file path is a controlled launch parameter.
+	if err != nil {
+		return nil, fmt.Errorf("could not read configuration file: %w", err)
+	}
+	return Decode(data)
+}
+
+// Decode decodes the MetricsExporterConfiguration from YAML or JSON data. Unknown fields are rejected.
+func Decode(data []byte) (*MetricsExporterConfiguration, error) {
+	config := &MetricsExporterConfiguration{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("could not decode configuration: %w", err)
+	}
+	if config.APIVersion != SchemeGroupVersion.String() || config.Kind != Kind {
+		return nil, fmt.Errorf("unsupported configuration %s, %s, expected %s, %s", config.APIVersion, config.Kind, SchemeGroupVersion.String(), Kind)
+	}
+	return config, nil
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GroupName is the group name of the configuration API.
+	GroupName = "metrics-exporter.config.gardener.cloud"
+	// Kind is the kind of the configuration object.
+	Kind = "MetricsExporterConfiguration"
+)
+
+// SchemeGroupVersion is the group version of the configuration API.
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+
+// MetricsExporterConfiguration defines the configuration for the gardener-metrics-exporter.
+type MetricsExporterConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+	// Server defines the configuration of the webserver.
+	Server ServerConfiguration `json:"server"`
+	// KubeClient defines the configuration of the client for the Garden cluster.
+	KubeClient KubeClientConfiguration `json:"kubeClient"`
+	// Gardens is the list of Garden clusters of several landscapes which are scraped. If empty, the Garden cluster
+	// of KubeClient.Kubeconfig is scraped and the metrics carry no landscape label.
+	Gardens []GardenConfiguration `json:"gardens,omitempty"`
+	// Collectors defines the configuration of the metric collectors.
+	Collectors CollectorsConfiguration `json:"collectors"`
+	// Labels defines how the values of metric labels are determined.
+	Labels LabelsConfiguration `json:"labels"`
+	// Logging defines the configuration of the logger.
+	Logging LoggingConfiguration `json:"logging"`
+	// LeaderElection defines the configuration of the leader election between multiple replicas.
+	LeaderElection LeaderElectionConfiguration `json:"leaderElection"`
+}
+
+// ServerConfiguration defines the configuration of the webserver.
+type ServerConfiguration struct {
+	// BindAddress is the IP address the webserver binds to.
+	BindAddress string `json:"bindAddress"`
+	// Port is the port the webserver listens on.
+	Port int `json:"port"`
+	// TLS enables TLS for the webserver if set.
+	TLS *TLSConfiguration `json:"tls,omitempty"`
+	// Authentication defines how requests to the metrics endpoint are authenticated.
+	Authentication AuthenticationConfiguration `json:"authentication"`
+	// Readiness defines when the readiness endpoint reports the exporter as ready.
+	Readiness ReadinessConfiguration `json:"readiness"`
+}
+
+// ReadinessConfiguration defines when the readiness endpoint reports the exporter as ready. The exporter is
+// ready once all informers have synced.
+type ReadinessConfiguration struct {
+	// WatchFailureThreshold is the duration after which a broken watch of an informer makes the exporter unready.
+	WatchFailureThreshold metav1.Duration `json:"watchFailureThreshold"`
+}
+
+// TLSConfiguration defines the certificates of the webserver. Changed files are reloaded.
+type TLSConfiguration struct {
+	// CertFile is the path to the serving certificate.
+	CertFile string `json:"certFile"`
+	// KeyFile is the path to the private key of the serving certificate.
+	KeyFile string `json:"keyFile"`
+	// ClientCAFile is the path to the CA bundle which verifies client certificates. If set, requests to the
+	// metrics endpoint are allowed with a verified client certificate.
+	ClientCAFile string `json:"clientCAFile,omitempty"`
+}
+
+// AuthenticationConfiguration defines how requests to the metrics endpoint are authenticated.
+type AuthenticationConfiguration struct {
+	// TokenReview allows requests with a bearer token which is authenticated by a TokenReview and authorized to
+	// get the metrics endpoint by a SubjectAccessReview. It requires TLS.
+	TokenReview bool `json:"tokenReview"`
+	// Kubeconfig is the path to a kubeconfig file for the cluster which reviews the tokens. The in-cluster
+	// configuration is used if empty.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
+
+// KubeClientConfiguration defines the configuration of the client for the Garden cluster.
+type KubeClientConfiguration struct {
+	// Kubeconfig is the path to a kubeconfig file for the Garden cluster. The in-cluster configuration is used if empty.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// QPS is the maximum number of queries per second to the Garden cluster.
+	QPS float32 `json:"qps"`
+	// Burst is the maximum burst of queries to the Garden cluster.
+	Burst int `json:"burst"`
+}
+
+// GardenConfiguration defines the Garden cluster of a landscape.
+type GardenConfiguration struct {
+	// Name is the name of the landscape. It is added as landscape label to all metrics of the Garden cluster.
+	Name string `json:"name"`
+	// Kubeconfig is the path to a kubeconfig file for the Garden cluster.
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+// CollectorsConfiguration defines the configuration of the metric collectors.
+type CollectorsConfiguration struct {
+	// Workers is the maximum number of collectors which run in parallel.
+	Workers int `json:"workers"`
+	// Timeout is the maximum duration of a single collector. A value of 0 disables the timeout.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// RefreshInterval is the minimum duration between two refreshes of the metrics snapshot. A value of 0 refreshes
+	// the snapshot right after each informer event.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// Disabled is the list of collectors which do not run.
+	Disabled []string `json:"disabled,omitempty"`
+	// Metrics filters the exposed metrics by their name.
+	Metrics MetricsFilter `json:"metrics"`
+}
+
+// MetricsFilter filters metrics by their name.
+type MetricsFilter struct {
+	// Allow is the list of metric names which are exposed. An empty list allows all metrics.
+	Allow []string `json:"allow,omitempty"`
+	// Deny is the list of metric names which are not exposed. It takes precedence over Allow.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// LabelsConfiguration defines how the values of metric labels are determined.
+type LabelsConfiguration struct {
+	// CostObjectAnnotation is the Project annotation which holds the cost object of a Shoot.
+	CostObjectAnnotation string `json:"costObjectAnnotation"`
+	// CostObjectTypeAnnotation is the Project annotation which holds the cost object type of a Shoot.
+	CostObjectTypeAnnotation string `json:"costObjectTypeAnnotation"`
+	// ErrorCodeClasses maps Shoot error codes to their class, one of user, infrastructure, gardener and unknown.
+	// The entries override the default classification of the exporter.
+	ErrorCodeClasses map[string]string `json:"errorCodeClasses,omitempty"`
+}
+
+// LoggingConfiguration defines the configuration of the logger.
+type LoggingConfiguration struct {
+	// Level is the log level. One of panic, fatal, error, warn, info, debug and trace.
+	Level string `json:"level"`
+	// Format is the log format. One of text and json.
+	Format string `json:"format"`
+}
+
+// LeaderElectionConfiguration defines the configuration of the leader election between multiple replicas.
+// Only the leader serves the Garden metrics.
+type LeaderElectionConfiguration struct {
+	// Enabled enables the leader election.
+	Enabled bool `json:"enabled"`
+	// LeaseName is the name of the Lease object in the Garden cluster.
+	LeaseName string `json:"leaseName"`
+	// LeaseNamespace is the namespace of the Lease object in the Garden cluster.
+	LeaseNamespace string `json:"leaseNamespace"`
+	// LeaseDuration is the duration non-leaders wait until they try to acquire the Lease.
+	LeaseDuration metav1.Duration `json:"leaseDuration"`
+	// RenewDeadline is the duration the leader retries to renew the Lease before it gives up leadership.
+	RenewDeadline metav1.Duration `json:"renewDeadline"`
+	// RetryPeriod is the duration candidates wait between two attempts to acquire or renew the Lease.
+	RetryPeriod metav1.Duration `json:"retryPeriod"`
+}
+
+const (
+	// LogFormatText is the log format for human-readable logs.
+	LogFormatText = "text"
+	// LogFormatJSON is the log format for structured logs.
+	LogFormatJSON = "json"
+)
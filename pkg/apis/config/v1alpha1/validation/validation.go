@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"maps"
+	"net"
+	"os"
+	"slices"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateMetricsExporterConfiguration validates the given MetricsExporterConfiguration and returns all problems.
+func ValidateMetricsExporterConfiguration(config *v1alpha1.MetricsExporterConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("apiVersion"), config.APIVersion, []string{v1alpha1.SchemeGroupVersion.String()}))
+	}
+	if config.Kind != v1alpha1.Kind {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("kind"), config.Kind, []string{v1alpha1.Kind}))
+	}
+
+	allErrs = append(allErrs, validateServerConfiguration(config.Server, field.NewPath("server"))...)
+	allErrs = append(allErrs, validateKubeClientConfiguration(config.KubeClient, field.NewPath("kubeClient"))...)
+	allErrs = append(allErrs, validateGardens(config.Gardens, config.KubeClient, field.NewPath("gardens"))...)
+	allErrs = append(allErrs, validateCollectorsConfiguration(config.Collectors, field.NewPath("collectors"))...)
+	allErrs = append(allErrs, validateLabelsConfiguration(config.Labels, field.NewPath("labels"))...)
+	allErrs = append(allErrs, validateLoggingConfiguration(config.Logging, field.NewPath("logging"))...)
+	allErrs = append(allErrs, validateLeaderElectionConfiguration(config.LeaderElection, field.NewPath("leaderElection"))...)
+
+	return allErrs
+}
+
+func validateServerConfiguration(server v1alpha1.ServerConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ip := net.ParseIP(server.BindAddress); ip == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bindAddress"), server.BindAddress, "must be a valid IP address"))
+	}
+	if server.Port < 1 || server.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), server.Port, "must be between 1 and 65535"))
+	}
+
+	if server.TLS != nil {
+		tlsPath := fldPath.Child("tls")
+		allErrs = append(allErrs, validateFile(server.TLS.CertFile, true, tlsPath.Child("certFile"))...)
+		allErrs = append(allErrs, validateFile(server.TLS.KeyFile, true, tlsPath.Child("keyFile"))...)
+		allErrs = append(allErrs, validateFile(server.TLS.ClientCAFile, false, tlsPath.Child("clientCAFile"))...)
+	}
+	if server.Authentication.TokenReview && server.TLS == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("authentication", "tokenReview"), "requires tls"))
+	}
+	allErrs = append(allErrs, validateFile(server.Authentication.Kubeconfig, false, fldPath.Child("authentication", "kubeconfig"))...)
+
+	if server.Readiness.WatchFailureThreshold.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("readiness", "watchFailureThreshold"), server.Readiness.WatchFailureThreshold.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
+// validateFile validates that the file exists, if a path is given or required.
+func validateFile(path string, required bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if path == "" {
+		if required {
+			allErrs = append(allErrs, field.Required(fldPath, "must not be empty"))
+		}
+		return allErrs
+	}
+	if _, err := os.Stat(path); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, path, "file does not exist"))
+	}
+
+	return allErrs
+}
+
+func validateKubeClientConfiguration(kubeClient v1alpha1.KubeClientConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if kubeClient.Kubeconfig != "" {
+		if _, err := os.Stat(kubeClient.Kubeconfig); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubeconfig"), kubeClient.Kubeconfig, "kubeconfig file does not exist"))
+		}
+	}
+	if kubeClient.QPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), kubeClient.QPS, "must not be negative"))
+	}
+	if kubeClient.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), kubeClient.Burst, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateGardens(gardens []v1alpha1.GardenConfiguration, kubeClient v1alpha1.KubeClientConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(gardens) > 0 && kubeClient.Kubeconfig != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("kubeClient", "kubeconfig"), "must not be set together with gardens"))
+	}
+
+	names := sets.New[string]()
+	for i, garden := range gardens {
+		idxPath := fldPath.Index(i)
+		if garden.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must not be empty"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(garden.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), garden.Name, msg))
+			}
+			if names.Has(garden.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), garden.Name))
+			}
+			names.Insert(garden.Name)
+		}
+		if garden.Kubeconfig == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("kubeconfig"), "must not be empty"))
+		} else if _, err := os.Stat(garden.Kubeconfig); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("kubeconfig"), garden.Kubeconfig, "kubeconfig file does not exist"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateCollectorsConfiguration(collectors v1alpha1.CollectorsConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if collectors.Workers < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("workers"), collectors.Workers, "must be at least 1"))
+	}
+	if collectors.Timeout != nil && collectors.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), collectors.Timeout.Duration.String(), "must not be negative"))
+	}
+	if collectors.RefreshInterval != nil && collectors.RefreshInterval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("refreshInterval"), collectors.RefreshInterval.Duration.String(), "must not be negative"))
+	}
+
+	disabled := sets.New[string]()
+	for i, collector := range collectors.Disabled {
+		idxPath := fldPath.Child("disabled").Index(i)
+		if !slices.Contains(names.CollectorNames, collector) {
+			allErrs = append(allErrs, field.NotSupported(idxPath, collector, names.CollectorNames))
+		}
+		if disabled.Has(collector) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, collector))
+		}
+		disabled.Insert(collector)
+	}
+
+	for i, metric := range collectors.Metrics.Allow {
+		if !slices.Contains(names.Metrics, metric) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metrics", "allow").Index(i), metric, "unknown metric"))
+		}
+	}
+	for i, metric := range collectors.Metrics.Deny {
+		if !slices.Contains(names.Metrics, metric) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metrics", "deny").Index(i), metric, "unknown metric"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateLabelsConfiguration(labels v1alpha1.LabelsConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if labels.CostObjectAnnotation == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("costObjectAnnotation"), "must not be empty"))
+	}
+	if labels.CostObjectTypeAnnotation == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("costObjectTypeAnnotation"), "must not be empty"))
+	}
+	for _, code := range slices.Sorted(maps.Keys(labels.ErrorCodeClasses)) {
+		class := labels.ErrorCodeClasses[code]
+		if code == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("errorCodeClasses"), code, "error code must not be empty"))
+		}
+		if !slices.Contains(names.ErrorClasses, class) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("errorCodeClasses").Key(code), class, names.ErrorClasses))
+		}
+	}
+
+	return allErrs
+}
+
+func validateLoggingConfiguration(logging v1alpha1.LoggingConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, err := logrus.ParseLevel(logging.Level); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("level"), logging.Level, err.Error()))
+	}
+	if logging.Format != v1alpha1.LogFormatText && logging.Format != v1alpha1.LogFormatJSON {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("format"), logging.Format, []string{v1alpha1.LogFormatText, v1alpha1.LogFormatJSON}))
+	}
+
+	return allErrs
+}
+
+func validateLeaderElectionConfiguration(leaderElection v1alpha1.LeaderElectionConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !leaderElection.Enabled {
+		return allErrs
+	}
+
+	if leaderElection.LeaseName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("leaseName"), "must not be empty"))
+	}
+	if leaderElection.LeaseNamespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("leaseNamespace"), "must not be empty"))
+	}
+	if leaderElection.RetryPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retryPeriod"), leaderElection.RetryPeriod.Duration.String(), "must be positive"))
+	}
+	if leaderElection.RenewDeadline.Duration <= leaderElection.RetryPeriod.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewDeadline"), leaderElection.RenewDeadline.Duration.String(), "must be greater than retryPeriod"))
+	}
+	if leaderElection.LeaseDuration.Duration <= leaderElection.RenewDeadline.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("leaseDuration"), leaderElection.LeaseDuration.Duration.String(), "must be greater than renewDeadline"))
+	}
+
+	return allErrs
+}
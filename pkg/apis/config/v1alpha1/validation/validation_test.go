@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/apis/config/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func newDefaultedConfiguration() *v1alpha1.MetricsExporterConfiguration {
+	config := &v1alpha1.MetricsExporterConfiguration{}
+	v1alpha1.SetDefaults_MetricsExporterConfiguration(config)
+	return config
+}
+
+func TestValidateMetricsExporterConfiguration_defaults(t *testing.T) {
+	if errs := ValidateMetricsExporterConfiguration(newDefaultedConfiguration()); len(errs) != 0 {
+		t.Errorf("expected the defaulted configuration to be valid, got %v", errs)
+	}
+}
+
+func TestValidateMetricsExporterConfiguration_reportsAllErrors(t *testing.T) {
+	config := newDefaultedConfiguration()
+	config.Server.BindAddress = "localhost"
+	config.Server.Port = 70000
+	config.Collectors.Workers = 0
+	config.Collectors.Timeout = &metav1.Duration{Duration: -time.Second}
+	config.Collectors.Disabled = []string{"shoots", "shoots", "unknown"}
+	config.Collectors.Metrics.Deny = []string{"garden_unknown"}
+	config.Labels.ErrorCodeClasses = map[string]string{"ERR_INFRA_QUOTA_EXCEEDED": "infrastructure", "ERR_UNKNOWN": "customer"}
+	config.Logging.Format = "xml"
+
+	expected := []string{
+		"server.bindAddress",
+		"server.port",
+		"collectors.workers",
+		"collectors.timeout",
+		"collectors.disabled[1]",
+		"collectors.disabled[2]",
+		"collectors.metrics.deny[0]",
+		"labels.errorCodeClasses[ERR_UNKNOWN]",
+		"logging.format",
+	}
+
+	errs := ValidateMetricsExporterConfiguration(config)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, err := range errs {
+		if err.Field != expected[i] {
+			t.Errorf("expected error %d for field %s, got %s", i, expected[i], err.Field)
+		}
+	}
+}
+
+func TestValidateMetricsExporterConfiguration_unsupportedKind(t *testing.T) {
+	config := newDefaultedConfiguration()
+	config.Kind = "Unknown"
+
+	errs := ValidateMetricsExporterConfiguration(config)
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeNotSupported || errs[0].Field != "kind" {
+		t.Errorf("expected a single not supported error for the kind, got %v", errs)
+	}
+}
+
+func TestValidateMetricsExporterConfiguration_portZero(t *testing.T) {
+	config := newDefaultedConfiguration()
+	config.Server.Port = 0
+
+	errs := ValidateMetricsExporterConfiguration(config)
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeInvalid || errs[0].Field != "server.port" {
+		t.Errorf("expected a single invalid error for the port, got %v", errs)
+	}
+}
+
+func TestValidateMetricsExporterConfiguration_gardens(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(kubeconfig, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := newDefaultedConfiguration()
+	config.KubeClient.Kubeconfig = kubeconfig
+	config.Gardens = []v1alpha1.GardenConfiguration{
+		{Name: "dev", Kubeconfig: kubeconfig},
+		{Name: "dev", Kubeconfig: kubeconfig},
+		{Name: "Live", Kubeconfig: kubeconfig},
+		{Name: "", Kubeconfig: ""},
+	}
+
+	expected := []string{
+		"kubeClient.kubeconfig",
+		"gardens[1].name",
+		"gardens[2].name",
+		"gardens[3].name",
+		"gardens[3].kubeconfig",
+	}
+
+	errs := ValidateMetricsExporterConfiguration(config)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, err := range errs {
+		if err.Field != expected[i] {
+			t.Errorf("expected error %d for field %s, got %s", i, expected[i], err.Field)
+		}
+	}
+}
+
+func TestValidateMetricsExporterConfiguration_tls(t *testing.T) {
+	config := newDefaultedConfiguration()
+	config.Server.Authentication.TokenReview = true
+	if errs := ValidateMetricsExporterConfiguration(config); len(errs) != 1 || errs[0].Field != "server.authentication.tokenReview" {
+		t.Errorf("expected a single error for the token review without TLS, got %v", errs)
+	}
+
+	config.Server.TLS = &v1alpha1.TLSConfiguration{KeyFile: filepath.Join(t.TempDir(), "tls.key")}
+	expected := []string{"server.tls.certFile", "server.tls.keyFile"}
+	errs := ValidateMetricsExporterConfiguration(config)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, err := range errs {
+		if err.Field != expected[i] {
+			t.Errorf("expected error %d for field %s, got %s", i, expected[i], err.Field)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	config, err := v1alpha1.Decode([]byte(`apiVersion: metrics-exporter.config.gardener.cloud/v1alpha1
+kind: MetricsExporterConfiguration
+server:
+  port: 8080
+collectors:
+  timeout: 5s
+  disabled:
+  - customization
+`))
+	if err != nil {
+		t.Fatalf("expected the configuration to decode, got %v", err)
+	}
+	if config.Server.Port != 8080 || config.Collectors.Timeout.Duration != 5*time.Second || len(config.Collectors.Disabled) != 1 {
+		t.Errorf("unexpected configuration %+v", config)
+	}
+
+	config, err = v1alpha1.Decode([]byte(`apiVersion: metrics-exporter.config.gardener.cloud/v1alpha1
+kind: MetricsExporterConfiguration
+collectors:
+  timeout: "0"
+  refreshInterval: 0s
+`))
+	if err != nil {
+		t.Fatalf("expected the configuration to decode, got %v", err)
+	}
+	v1alpha1.SetDefaults_MetricsExporterConfiguration(config)
+	if config.Collectors.Timeout.Duration != 0 || config.Collectors.RefreshInterval.Duration != 0 {
+		t.Errorf("expected an explicit timeout and refresh interval of 0 to be kept, got %s and %s", config.Collectors.Timeout, config.Collectors.RefreshInterval)
+	}
+
+	if _, err := v1alpha1.Decode([]byte(`apiVersion: metrics-exporter.config.gardener.cloud/v1alpha1
+kind: MetricsExporterConfiguration
+unknown: true
+`)); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// livenessThreshold is the duration after which a loop without heartbeat is considered to be wedged.
+const livenessThreshold = 5 * time.Minute
+
+// informer is the part of a shared informer which is needed to determine its health.
+type informer interface {
+	HasSynced() bool
+}
+
+// informerState is the watch state of a tracked informer.
+type informerState struct {
+	informer informer
+	// brokenSince is the time of the first watch error since the last successful list or watch request.
+	brokenSince time.Time
+}
+
+// Tracker tracks the sync and watch state of informers to determine the readiness of the exporter, and the
+// heartbeats of its background loops to determine its liveness.
+type Tracker struct {
+	threshold time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	informers  map[string]*informerState
+	heartbeats map[string]time.Time
+}
+
+// NewTracker creates a new Tracker. Informers whose watch is broken longer than the threshold are unhealthy.
+func NewTracker(threshold time.Duration) *Tracker {
+	return &Tracker{
+		threshold:  threshold,
+		now:        time.Now,
+		informers:  make(map[string]*informerState),
+		heartbeats: make(map[string]time.Time),
+	}
+}
+
+// trackerKey returns the key of the resource or loop in the Garden cluster of the landscape.
+func trackerKey(landscape, name string) string {
+	if landscape == "" {
+		return name
+	}
+	return landscape + "/" + name
+}
+
+// Track adds the informer of the resource in the Garden cluster of the landscape. It must be called before
+// the informer is started.
+func (t *Tracker) Track(landscape, resource string, sharedInformer cache.SharedIndexInformer) error {
+	key := trackerKey(landscape, resource)
+	t.track(key, sharedInformer)
+	return sharedInformer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		t.watchFailed(key)
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+	})
+}
+
+func (t *Tracker) track(key string, informer informer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.informers[key] = &informerState{informer: informer}
+}
+
+// watchFailed records a watch error of the informer.
+func (t *Tracker) watchFailed(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.informers[key]
+	if !ok || !state.brokenSince.IsZero() {
+		return
+	}
+	state.brokenSince = t.now()
+}
+
+// listWatchSucceeded records a successful list or watch request of the informer, which heals a broken watch.
+func (t *Tracker) listWatchSucceeded(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state, ok := t.informers[key]; ok {
+		state.brokenSince = time.Time{}
+	}
+}
+
+// WrapTransport returns a wrapper for the transport of the clients of the informers in the Garden cluster of the
+// landscape. It records the successful list and watch requests of the tracked informers.
+func (t *Tracker) WrapTransport(landscape string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &listWatchTransport{tracker: t, landscape: landscape, next: rt}
+	}
+}
+
+// listWatchTransport records the successful list and watch requests of the tracked informers.
+type listWatchTransport struct {
+	tracker   *Tracker
+	landscape string
+	next      http.RoundTripper
+}
+
+func (l *listWatchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := l.next.RoundTrip(req)
+	if err == nil && req.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		// The informers list and watch all namespaces, so the resource is the last segment of the path.
+		l.tracker.listWatchSucceeded(trackerKey(l.landscape, path.Base(req.URL.Path)))
+	}
+	return resp, err
+}
+
+// Heartbeat records that the loop with the given name in the Garden cluster of the landscape is alive.
+func (t *Tracker) Heartbeat(landscape, name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.heartbeats[trackerKey(landscape, name)] = t.now()
+}
+
+// Live returns an error if a loop did not report a heartbeat for longer than the liveness threshold.
+func (t *Tracker) Live() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(t.heartbeats)) {
+		if silent := t.now().Sub(t.heartbeats[key]); silent > livenessThreshold {
+			errs = append(errs, fmt.Errorf("%s did not report a heartbeat for %s", key, silent.Round(time.Second)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Ready returns an error if an informer has not synced yet or if its watch is broken longer than the threshold.
+func (t *Tracker) Ready() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(t.informers)) {
+		state := t.informers[key]
+		if !state.informer.HasSynced() {
+			errs = append(errs, fmt.Errorf("informer for %s has not synced", key))
+			continue
+		}
+		if state.brokenSince.IsZero() {
+			continue
+		}
+		if broken := t.now().Sub(state.brokenSince); broken > t.threshold {
+			errs = append(errs, fmt.Errorf("watch for %s is broken since %s", key, broken.Round(time.Second)))
+		}
+	}
+	return errors.Join(errs...)
+}
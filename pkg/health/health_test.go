@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeInformer struct {
+	synced bool
+}
+
+func (f *fakeInformer) HasSynced() bool { return f.synced }
+
+// statusTransport answers all requests with the given status code.
+type statusTransport int
+
+func (s statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: int(s), Request: req}, nil
+}
+
+func watchRequest(t *testing.T, transport http.RoundTripper) {
+	req, err := http.NewRequest(http.MethodGet, "https://garden/apis/core.gardener.cloud/v1beta1/shoots?watch=true", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTracker_Ready(t *testing.T) {
+	var (
+		now      = time.Now()
+		informer = &fakeInformer{}
+		tracker  = NewTracker(time.Minute)
+	)
+	tracker.now = func() time.Time { return now }
+	tracker.track("dev/shoots", informer)
+
+	if err := tracker.Ready(); err == nil {
+		t.Error("expected the tracker not to be ready before the informer synced")
+	}
+
+	informer.synced = true
+	if err := tracker.Ready(); err != nil {
+		t.Errorf("expected the tracker to be ready after the informer synced, got %v", err)
+	}
+
+	tracker.watchFailed("dev/shoots")
+	now = now.Add(30 * time.Second)
+	tracker.watchFailed("dev/shoots")
+	if err := tracker.Ready(); err != nil {
+		t.Errorf("expected the tracker to be ready while the watch is broken shorter than the threshold, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := tracker.Ready(); err == nil {
+		t.Error("expected the tracker not to be ready once the watch is broken longer than the threshold")
+	}
+
+	watchRequest(t, tracker.WrapTransport("dev")(statusTransport(http.StatusInternalServerError)))
+	if err := tracker.Ready(); err == nil {
+		t.Error("expected the tracker not to be ready after a failed watch request")
+	}
+
+	watchRequest(t, tracker.WrapTransport("dev")(statusTransport(http.StatusOK)))
+	if err := tracker.Ready(); err != nil {
+		t.Errorf("expected the tracker to be ready once a watch request succeeded, got %v", err)
+	}
+}
+
+func TestTracker_Live(t *testing.T) {
+	var (
+		now     = time.Now()
+		tracker = NewTracker(time.Minute)
+	)
+	tracker.now = func() time.Time { return now }
+
+	if err := tracker.Live(); err != nil {
+		t.Errorf("expected the tracker to be live without loops, got %v", err)
+	}
+
+	tracker.Heartbeat("dev", "snapshot")
+	now = now.Add(livenessThreshold)
+	if err := tracker.Live(); err != nil {
+		t.Errorf("expected the tracker to be live within the liveness threshold, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if err := tracker.Live(); err == nil {
+		t.Error("expected the tracker not to be live once a loop did not report a heartbeat for longer than the threshold")
+	}
+
+	tracker.Heartbeat("dev", "snapshot")
+	if err := tracker.Live(); err != nil {
+		t.Errorf("expected the tracker to be live after a heartbeat, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Config defines the Lease and the timings of the leader election.
+type Config struct {
+	// LeaseName is the name of the Lease object.
+	LeaseName string
+	// LeaseNamespace is the namespace of the Lease object.
+	LeaseNamespace string
+	// Identity is the unique identity of the candidate.
+	Identity string
+	// LeaseDuration is the duration non-leaders wait until they try to acquire the Lease.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration the leader retries to renew the Lease before it gives up leadership.
+	RenewDeadline time.Duration
+	// RetryPeriod is the duration candidates wait between two attempts to acquire or renew the Lease.
+	RetryPeriod time.Duration
+}
+
+// Run takes part in the leader election until the context is cancelled. onChange is called whenever the
+// candidate becomes leader or loses the leadership. After losing the leadership the candidate tries to
+// acquire the Lease again, so that it is ready to take over quickly.
+func Run(ctx context.Context, client kubernetes.Interface, config Config, onChange func(leading bool), logger *logrus.Logger) error {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      config.LeaseName,
+			Namespace: config.LeaseNamespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: config.Identity,
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            config.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(_ context.Context) {
+				logger.Infof("Acquired leadership of Lease %s/%s.", config.LeaseNamespace, config.LeaseName)
+				onChange(true)
+			},
+			OnStoppedLeading: func() {
+				logger.Infof("Lost leadership of Lease %s/%s.", config.LeaseNamespace, config.LeaseName)
+				onChange(false)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != config.Identity {
+					logger.Infof("Current leader of Lease %s/%s is %s.", config.LeaseNamespace, config.LeaseName, identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The elector returns as soon as the leadership is lost. Rejoin the election until the context is cancelled.
+	for ctx.Err() == nil {
+		elector.Run(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestConfig(identity string) Config {
+	return Config{
+		LeaseName:      "gardener-metrics-exporter",
+		LeaseNamespace: "garden",
+		Identity:       identity,
+		LeaseDuration:  time.Second,
+		RenewDeadline:  500 * time.Millisecond,
+		RetryPeriod:    100 * time.Millisecond,
+	}
+}
+
+func startCandidate(ctx context.Context, t *testing.T, client *fake.Clientset, identity string) (<-chan bool, <-chan struct{}) {
+	var (
+		changes = make(chan bool, 10)
+		done    = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		if err := Run(ctx, client, newTestConfig(identity), func(leading bool) { changes <- leading }, logrus.New()); err != nil {
+			t.Errorf("leader election of %s failed: %v", identity, err)
+		}
+	}()
+	return changes, done
+}
+
+func TestRun(t *testing.T) {
+	var (
+		client                 = fake.NewClientset()
+		leaderCtx, stopLeader  = context.WithCancel(context.Background())
+		followerCtx, cancelAll = context.WithCancel(context.Background())
+	)
+	defer stopLeader()
+	defer cancelAll()
+
+	leaderChanges, leaderDone := startCandidate(leaderCtx, t, client, "leader")
+	select {
+	case leading := <-leaderChanges:
+		if !leading {
+			t.Fatal("expected the first candidate to become leader")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the first candidate to become leader")
+	}
+
+	followerChanges, _ := startCandidate(followerCtx, t, client, "follower")
+	select {
+	case <-followerChanges:
+		t.Fatal("expected the second candidate not to become leader while the first one renews the Lease")
+	case <-time.After(1500 * time.Millisecond):
+	}
+
+	// Stopping the leader releases the Lease, so that the follower takes over.
+	stopLeader()
+	<-leaderDone
+	select {
+	case leading := <-followerChanges:
+		if !leading {
+			t.Fatal("expected the second candidate to become leader")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the second candidate to take over")
+	}
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"slices"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// collectBackupBucketMetrics collects BackupBucket metrics.
+func (c *gardenMetricsCollector) collectBackupBucketMetrics(ch chan<- prometheus.Metric) {
+	backupBuckets, err := c.backupBucketInformer.Lister().List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "backupbuckets"}).Inc()
+		return
+	}
+
+	for _, backupBucket := range backupBuckets {
+		generateBackupBucketMetrics(backupBucket, c.errorClassifier, c.descs, c.scrapeFailures, ch)
+	}
+}
+
+func generateBackupBucketMetrics(backupBucket *gardenv1beta1.BackupBucket, classifier errorClassifier, descs map[string]*prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	metric, err := prometheus.NewConstMetric(
+		descs[metricGardenBackupBucketInfo],
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			backupBucket.Name,
+			backupBucket.Spec.Provider.Type,
+			backupBucket.Spec.Provider.Region,
+			ptr.Deref(backupBucket.Spec.SeedName, ""),
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "backupbuckets"}).Inc()
+	} else {
+		ch <- metric
+	}
+
+	generateOperationStateMetrics(backupBucket.Status.LastOperation, descs[metricGardenBackupBucketOperationState], "backupbuckets", scrapeFailures, ch, backupBucket.Name)
+	generateLastErrorMetrics(backupBucket.Status.LastError, classifier, descs[metricGardenBackupBucketLastError], "backupbuckets", scrapeFailures, ch, backupBucket.Name)
+}
+
+// collectBackupEntryMetrics collects BackupEntry metrics.
+func (c *gardenMetricsCollector) collectBackupEntryMetrics(ch chan<- prometheus.Metric) {
+	backupEntries, err := c.backupEntryInformer.Lister().BackupEntries(metav1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "backupentries"}).Inc()
+		return
+	}
+
+	backupBuckets, err := c.backupBucketInformer.Lister().List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "backupbuckets"}).Inc()
+		return
+	}
+	backupBucketMap := make(map[string]*gardenv1beta1.BackupBucket, len(backupBuckets))
+	for _, backupBucket := range backupBuckets {
+		backupBucketMap[backupBucket.Name] = backupBucket
+	}
+
+	for _, backupEntry := range backupEntries {
+		var (
+			backupBucket = backupBucketMap[backupEntry.Spec.BucketName]
+			orphaned     *bool
+		)
+		if shootRef := backupEntryShootReference(backupEntry); shootRef != nil {
+			shoot, err := c.shootInformer.Lister().Shoots(backupEntry.Namespace).Get(shootRef.Name)
+			orphaned = ptr.To(err != nil || (shootRef.UID != "" && shoot.UID != shootRef.UID))
+		}
+		generateBackupEntryMetrics(backupEntry, backupBucket, orphaned, c.errorClassifier, c.descs, c.scrapeFailures, ch)
+	}
+}
+
+// generateBackupEntryMetrics exposes the metrics of the BackupEntry. The provider is taken from its BackupBucket, if
+// it exists. The orphan indicator is only exposed if it is known whether the Shoot of the BackupEntry exists.
+func generateBackupEntryMetrics(backupEntry *gardenv1beta1.BackupEntry, backupBucket *gardenv1beta1.BackupBucket, orphaned *bool, classifier errorClassifier,
+	descs map[string]*prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	var iaas, region string
+	if backupBucket != nil {
+		iaas = backupBucket.Spec.Provider.Type
+		region = backupBucket.Spec.Provider.Region
+	}
+
+	metric, err := prometheus.NewConstMetric(
+		descs[metricGardenBackupEntryInfo],
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			backupEntry.Name,
+			backupEntry.Namespace,
+			backupEntry.Spec.BucketName,
+			iaas,
+			region,
+			ptr.Deref(backupEntry.Spec.SeedName, ""),
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "backupentries"}).Inc()
+	} else {
+		ch <- metric
+	}
+
+	generateOperationStateMetrics(backupEntry.Status.LastOperation, descs[metricGardenBackupEntryOperationState], "backupentries", scrapeFailures, ch, backupEntry.Name, backupEntry.Namespace)
+	generateLastErrorMetrics(backupEntry.Status.LastError, classifier, descs[metricGardenBackupEntryLastError], "backupentries", scrapeFailures, ch, backupEntry.Name, backupEntry.Namespace)
+
+	if orphaned == nil {
+		return
+	}
+	var orphanedVal float64
+	if *orphaned {
+		orphanedVal = 1
+	}
+	metric, err = prometheus.NewConstMetric(
+		descs[metricGardenBackupEntryOrphaned],
+		prometheus.GaugeValue,
+		orphanedVal,
+		[]string{
+			backupEntry.Name,
+			backupEntry.Namespace,
+			backupEntryShootReference(backupEntry).Name,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "backupentries"}).Inc()
+		return
+	}
+	ch <- metric
+}
+
+// backupEntryShootReference returns the Shoot which owns the BackupEntry, nil if it is unknown.
+func backupEntryShootReference(backupEntry *gardenv1beta1.BackupEntry) *corev1.ObjectReference {
+	for _, ownerReference := range backupEntry.OwnerReferences {
+		if ownerReference.Kind == "Shoot" && ownerReference.APIVersion == gardenv1beta1.SchemeGroupVersion.String() {
+			return &corev1.ObjectReference{Name: ownerReference.Name, UID: ownerReference.UID}
+		}
+	}
+	return backupEntry.Spec.ShootRef
+}
+
+// generateLastErrorMetrics exposes a metric per error code of the last error, whose value is the time the error was
+// reported last. The code and its class are the last labels of the desc.
+func generateLastErrorMetrics(lastError *gardenv1beta1.LastError, classifier errorClassifier, desc *prometheus.Desc, kind string, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric, labelValues ...string) {
+	if lastError == nil {
+		return
+	}
+
+	var lastUpdateTime float64
+	if lastError.LastUpdateTime != nil {
+		lastUpdateTime = float64(lastError.LastUpdateTime.Unix())
+	}
+
+	codes := lastError.Codes
+	if len(codes) == 0 {
+		codes = []gardenv1beta1.ErrorCode{""}
+	}
+	for _, code := range codes {
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			lastUpdateTime,
+			append(slices.Clone(labelValues), string(code), classifier.classify(code))...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": kind}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_generateBackupBucketMetrics(t *testing.T) {
+	lastUpdateTime := metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	backupBucket := &gardenv1beta1.BackupBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-bucket"},
+		Spec: gardenv1beta1.BackupBucketSpec{
+			Provider: gardenv1beta1.BackupBucketProvider{Type: "aws", Region: "eu-west-1"},
+			SeedName: ptr.To("test-seed"),
+		},
+		Status: gardenv1beta1.BackupBucketStatus{
+			LastOperation: &gardenv1beta1.LastOperation{Type: gardenv1beta1.LastOperationTypeReconcile, State: gardenv1beta1.LastOperationStateError},
+			LastError: &gardenv1beta1.LastError{
+				Codes:          []gardenv1beta1.ErrorCode{gardenv1beta1.ErrorInfraUnauthorized},
+				LastUpdateTime: &lastUpdateTime,
+			},
+		},
+	}
+
+	var (
+		descs = getGardenMetricsDefinitions()
+		ch    = make(chan prometheus.Metric, 10)
+	)
+	generateBackupBucketMetrics(backupBucket, newErrorClassifier(nil), descs, testScrapeFailures, ch)
+
+	if len(ch) != 1+len(seedOperations)+1 {
+		t.Fatalf("expected an info, %d operation state and a last error metric, got %d", len(seedOperations), len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenBackupBucketInfo], prometheus.GaugeValue, 0, "test-bucket", "aws", "eu-west-1", "test-seed")
+	assert(t, <-ch, expected)
+	for _, operation := range seedOperations {
+		var state float64
+		if operation == string(gardenv1beta1.LastOperationTypeReconcile) {
+			state = 5
+		}
+		expected, _ := prometheus.NewConstMetric(descs[metricGardenBackupBucketOperationState], prometheus.GaugeValue, state, "test-bucket", operation)
+		assert(t, <-ch, expected)
+	}
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenBackupBucketLastError], prometheus.GaugeValue, float64(lastUpdateTime.Unix()),
+		"test-bucket", string(gardenv1beta1.ErrorInfraUnauthorized), names.ErrorClassUser,
+	)
+	assert(t, <-ch, expected)
+}
+
+func Test_generateBackupEntryMetrics(t *testing.T) {
+	backupEntry := &gardenv1beta1.BackupEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shoot--test--test-shoot--uid",
+			Namespace: "garden-test",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: gardenv1beta1.SchemeGroupVersion.String(), Kind: "Shoot", Name: "test-shoot", UID: "uid"},
+			},
+		},
+		Spec: gardenv1beta1.BackupEntrySpec{
+			BucketName: "test-bucket",
+			SeedName:   ptr.To("test-seed"),
+		},
+	}
+	backupBucket := &gardenv1beta1.BackupBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-bucket"},
+		Spec:       gardenv1beta1.BackupBucketSpec{Provider: gardenv1beta1.BackupBucketProvider{Type: "aws", Region: "eu-west-1"}},
+	}
+
+	var (
+		descs = getGardenMetricsDefinitions()
+		ch    = make(chan prometheus.Metric, 2)
+	)
+	generateBackupEntryMetrics(backupEntry, backupBucket, ptr.To(true), newErrorClassifier(nil), descs, testScrapeFailures, ch)
+
+	if len(ch) != 2 {
+		t.Fatalf("expected an info and an orphan metric, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenBackupEntryInfo], prometheus.GaugeValue, 0,
+		"shoot--test--test-shoot--uid", "garden-test", "test-bucket", "aws", "eu-west-1", "test-seed",
+	)
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenBackupEntryOrphaned], prometheus.GaugeValue, 1,
+		"shoot--test--test-shoot--uid", "garden-test", "test-shoot",
+	)
+	assert(t, <-ch, expected)
+}
+
+func Test_backupEntryShootReference(t *testing.T) {
+	backupEntry := &gardenv1beta1.BackupEntry{
+		Spec: gardenv1beta1.BackupEntrySpec{ShootRef: &corev1.ObjectReference{Name: "test-shoot"}},
+	}
+	assert(t, backupEntryShootReference(backupEntry), &corev1.ObjectReference{Name: "test-shoot"})
+
+	backupEntry.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "v1", Kind: "Namespace", Name: "garden-test"},
+		{APIVersion: gardenv1beta1.SchemeGroupVersion.String(), Kind: "Shoot", Name: "owner", UID: "uid"},
+	}
+	assert(t, backupEntryShootReference(backupEntry), &corev1.ObjectReference{Name: "owner", UID: "uid"})
+
+	assert(t, backupEntryShootReference(&gardenv1beta1.BackupEntry{}), (*corev1.ObjectReference)(nil))
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// collectCloudProfileMetrics collects CloudProfile metrics.
+func (c *gardenMetricsCollector) collectCloudProfileMetrics(ch chan<- prometheus.Metric) {
+	cloudProfiles, err := c.cloudProfileInformer.Lister().List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+		return
+	}
+
+	for _, cloudProfile := range cloudProfiles {
+		generateCloudProfileMetrics(cloudProfile.Name, &cloudProfile.Spec, c.descs, c.scrapeFailures, ch)
+	}
+}
+
+// generateCloudProfileMetrics exposes what the given CloudProfile spec offers: its Kubernetes and machine image
+// versions with their classification and expiration, its machine types, volume types and regions.
+func generateCloudProfileMetrics(name string, spec *gardenv1beta1.CloudProfileSpec, descs map[string]*prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, version := range spec.Kubernetes.Versions {
+		metric, err := prometheus.NewConstMetric(
+			descs[metricGardenCloudProfileKubernetesVersionInfo],
+			prometheus.GaugeValue,
+			0,
+			[]string{
+				name,
+				spec.Type,
+				version.Version,
+				string(v1beta1helper.CurrentLifecycleClassification(version)),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+			continue
+		}
+		ch <- metric
+
+		expiration := versionExpiration(version)
+		if expiration == nil {
+			continue
+		}
+		metric, err = prometheus.NewConstMetric(
+			descs[metricGardenCloudProfileKubernetesVersionExpiration],
+			prometheus.GaugeValue,
+			float64(expiration.Unix()),
+			[]string{
+				name,
+				spec.Type,
+				version.Version,
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+
+	for _, image := range spec.MachineImages {
+		for _, version := range image.Versions {
+			architectures := slices.Clone(v1beta1helper.GetArchitecturesFromImageVersion(version, spec.MachineCapabilities))
+			slices.Sort(architectures)
+			metric, err := prometheus.NewConstMetric(
+				descs[metricGardenCloudProfileMachineImageVersionInfo],
+				prometheus.GaugeValue,
+				0,
+				[]string{
+					name,
+					spec.Type,
+					image.Name,
+					version.Version,
+					string(v1beta1helper.CurrentLifecycleClassification(version.ExpirableVersion)),
+					strings.Join(architectures, ","),
+				}...,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+				continue
+			}
+			ch <- metric
+
+			expiration := versionExpiration(version.ExpirableVersion)
+			if expiration == nil {
+				continue
+			}
+			metric, err = prometheus.NewConstMetric(
+				descs[metricGardenCloudProfileMachineImageVersionExpiration],
+				prometheus.GaugeValue,
+				float64(expiration.Unix()),
+				[]string{
+					name,
+					spec.Type,
+					image.Name,
+					version.Version,
+				}...,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
+	}
+
+	for _, machineType := range spec.MachineTypes {
+		metric, err := prometheus.NewConstMetric(
+			descs[metricGardenCloudProfileMachineTypeInfo],
+			prometheus.GaugeValue,
+			0,
+			[]string{
+				name,
+				spec.Type,
+				machineType.Name,
+				ptr.Deref(machineType.Architecture, ""),
+				strconv.FormatBool(ptr.Deref(machineType.Usable, true)),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+			continue
+		}
+		ch <- metric
+
+		for _, capacity := range []struct {
+			resource string
+			quantity resource.Quantity
+		}{
+			{"cpu", machineType.CPU},
+			{"gpu", machineType.GPU},
+			{"memory", machineType.Memory},
+		} {
+			metric, err := prometheus.NewConstMetric(
+				descs[metricGardenCloudProfileMachineTypeCapacity],
+				prometheus.GaugeValue,
+				capacity.quantity.AsApproximateFloat64(),
+				[]string{
+					name,
+					spec.Type,
+					machineType.Name,
+					capacity.resource,
+				}...,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
+	}
+
+	for _, volumeType := range spec.VolumeTypes {
+		metric, err := prometheus.NewConstMetric(
+			descs[metricGardenCloudProfileVolumeTypeInfo],
+			prometheus.GaugeValue,
+			0,
+			[]string{
+				name,
+				spec.Type,
+				volumeType.Name,
+				volumeType.Class,
+				strconv.FormatBool(ptr.Deref(volumeType.Usable, true)),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+
+	for _, region := range spec.Regions {
+		zones := make([]string, 0, len(region.Zones))
+		for _, zone := range region.Zones {
+			zones = append(zones, zone.Name)
+		}
+		slices.Sort(zones)
+		metric, err := prometheus.NewConstMetric(
+			descs[metricGardenCloudProfileRegionInfo],
+			prometheus.GaugeValue,
+			0,
+			[]string{
+				name,
+				spec.Type,
+				region.Name,
+				strings.Join(zones, ","),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "cloudprofiles"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// versionExpiration returns the time at which the version expires, nil if it does not expire. It is either the
+// start of the expired lifecycle stage or the deprecated expiration date.
+func versionExpiration(version gardenv1beta1.ExpirableVersion) *metav1.Time {
+	for _, stage := range version.Lifecycle {
+		if stage.Classification == gardenv1beta1.ClassificationExpired {
+			return stage.StartTime
+		}
+	}
+	return version.ExpirationDate
+}
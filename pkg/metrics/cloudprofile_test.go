@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_generateCloudProfileMetrics(t *testing.T) {
+	expirationDate := metav1.NewTime(time.Now().Add(24 * time.Hour).Truncate(time.Second))
+	spec := &gardenv1beta1.CloudProfileSpec{
+		Type: "aws",
+		Kubernetes: gardenv1beta1.KubernetesSettings{
+			Versions: []gardenv1beta1.ExpirableVersion{
+				{Version: "1.31.1", Classification: ptr.To(gardenv1beta1.ClassificationDeprecated), ExpirationDate: &expirationDate},
+			},
+		},
+		MachineImages: []gardenv1beta1.MachineImage{{
+			Name: "gardenlinux",
+			Versions: []gardenv1beta1.MachineImageVersion{{
+				ExpirableVersion: gardenv1beta1.ExpirableVersion{
+					Version: "1592.1.0",
+					Lifecycle: []gardenv1beta1.LifecycleStage{
+						{Classification: gardenv1beta1.ClassificationSupported},
+						{Classification: gardenv1beta1.ClassificationExpired, StartTime: &expirationDate},
+					},
+				},
+				Architectures: []string{"arm64", "amd64"},
+			}},
+		}},
+		MachineTypes: []gardenv1beta1.MachineType{{
+			Name:         "m5.large",
+			CPU:          resource.MustParse("2"),
+			GPU:          resource.MustParse("0"),
+			Memory:       resource.MustParse("8Gi"),
+			Architecture: ptr.To("amd64"),
+		}},
+		VolumeTypes: []gardenv1beta1.VolumeType{{Name: "gp3", Class: "standard", Usable: ptr.To(false)}},
+		Regions: []gardenv1beta1.Region{{
+			Name:  "eu-west-1",
+			Zones: []gardenv1beta1.AvailabilityZone{{Name: "eu-west-1b"}, {Name: "eu-west-1a"}},
+		}},
+	}
+
+	var (
+		descs = getGardenMetricsDefinitions()
+		ch    = make(chan prometheus.Metric, 20)
+	)
+	generateCloudProfileMetrics("aws", spec, descs, testScrapeFailures, ch)
+	close(ch)
+
+	expected := []struct {
+		desc        string
+		value       float64
+		labelValues []string
+	}{
+		{metricGardenCloudProfileKubernetesVersionInfo, 0, []string{"aws", "aws", "1.31.1", "deprecated"}},
+		{metricGardenCloudProfileKubernetesVersionExpiration, float64(expirationDate.Unix()), []string{"aws", "aws", "1.31.1"}},
+		{metricGardenCloudProfileMachineImageVersionInfo, 0, []string{"aws", "aws", "gardenlinux", "1592.1.0", "supported", "amd64,arm64"}},
+		{metricGardenCloudProfileMachineImageVersionExpiration, float64(expirationDate.Unix()), []string{"aws", "aws", "gardenlinux", "1592.1.0"}},
+		{metricGardenCloudProfileMachineTypeInfo, 0, []string{"aws", "aws", "m5.large", "amd64", "true"}},
+		{metricGardenCloudProfileMachineTypeCapacity, 2, []string{"aws", "aws", "m5.large", "cpu"}},
+		{metricGardenCloudProfileMachineTypeCapacity, 0, []string{"aws", "aws", "m5.large", "gpu"}},
+		{metricGardenCloudProfileMachineTypeCapacity, 8 * 1024 * 1024 * 1024, []string{"aws", "aws", "m5.large", "memory"}},
+		{metricGardenCloudProfileVolumeTypeInfo, 0, []string{"aws", "aws", "gp3", "standard", "false"}},
+		{metricGardenCloudProfileRegionInfo, 0, []string{"aws", "aws", "eu-west-1", "eu-west-1a,eu-west-1b"}},
+	}
+
+	if len(ch) != len(expected) {
+		t.Fatalf("expected %d metrics, got %d", len(expected), len(ch))
+	}
+	for _, e := range expected {
+		metric, _ := prometheus.NewConstMetric(descs[e.desc], prometheus.GaugeValue, e.value, e.labelValues...)
+		assert(t, <-ch, metric)
+	}
+}
+
+func Test_versionExpiration(t *testing.T) {
+	expirationDate := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	assert(t, versionExpiration(gardenv1beta1.ExpirableVersion{Version: "1.31.1"}), (*metav1.Time)(nil))
+	assert(t, versionExpiration(gardenv1beta1.ExpirableVersion{Version: "1.31.1", ExpirationDate: &expirationDate}), &expirationDate)
+	assert(t, versionExpiration(gardenv1beta1.ExpirableVersion{
+		Version: "1.31.1",
+		Lifecycle: []gardenv1beta1.LifecycleStage{
+			{Classification: gardenv1beta1.ClassificationDeprecated},
+			{Classification: gardenv1beta1.ClassificationExpired, StartTime: &expirationDate},
+		},
+	}), &expirationDate)
+}
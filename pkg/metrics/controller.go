@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strconv"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// collectControllerRegistrationMetrics collects ControllerRegistration metrics.
+func (c *gardenMetricsCollector) collectControllerRegistrationMetrics(ch chan<- prometheus.Metric) {
+	controllerRegistrations, err := c.controllerRegistrationInformer.Lister().List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "controllerregistrations"}).Inc()
+		return
+	}
+
+	generateControllerRegistrationMetrics(controllerRegistrations, c.descs[metricGardenControllerRegistrationResource], c.scrapeFailures, ch)
+}
+
+func generateControllerRegistrationMetrics(controllerRegistrations []*gardenv1beta1.ControllerRegistration, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, controllerRegistration := range controllerRegistrations {
+		// Expose a metric for each resource the extension provides.
+		for _, resource := range controllerRegistration.Spec.Resources {
+			metric, err := prometheus.NewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				0,
+				[]string{
+					controllerRegistration.Name,
+					resource.Kind,
+					resource.Type,
+					strconv.FormatBool(ptr.Deref(resource.Primary, true)),
+				}...,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "controllerregistrations"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
+	}
+}
+
+// collectControllerInstallationMetrics collects ControllerInstallation metrics.
+func (c *gardenMetricsCollector) collectControllerInstallationMetrics(ch chan<- prometheus.Metric) {
+	controllerInstallations, err := c.controllerInstallationInformer.Lister().List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "controllerinstallations"}).Inc()
+		return
+	}
+
+	generateControllerInstallationMetrics(controllerInstallations, c.descs[metricGardenControllerInstallationCondition], c.scrapeFailures, ch)
+}
+
+func generateControllerInstallationMetrics(controllerInstallations []*gardenv1beta1.ControllerInstallation, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, controllerInstallation := range controllerInstallations {
+		var seed, shoot string
+		if controllerInstallation.Spec.SeedRef != nil {
+			seed = controllerInstallation.Spec.SeedRef.Name
+		}
+		if shootRef := controllerInstallation.Spec.ShootRef; shootRef != nil {
+			shoot = shootRef.Namespace + "/" + shootRef.Name
+		}
+
+		// Export a metric for each condition of the ControllerInstallation.
+		for _, condition := range controllerInstallation.Status.Conditions {
+			if condition.Type == "" {
+				continue
+			}
+			metric, err := prometheus.NewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				mapConditionStatus(condition.Status),
+				[]string{
+					controllerInstallation.Name,
+					controllerInstallation.Spec.RegistrationRef.Name,
+					seed,
+					shoot,
+					string(condition.Type),
+				}...,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "controllerinstallations"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
+	}
+}
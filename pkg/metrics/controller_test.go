@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_generateControllerRegistrationMetrics(t *testing.T) {
+	controllerRegistration := &gardenv1beta1.ControllerRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider-aws"},
+		Spec: gardenv1beta1.ControllerRegistrationSpec{
+			Resources: []gardenv1beta1.ControllerResource{
+				{Kind: "Infrastructure", Type: "aws"},
+				{Kind: "Extension", Type: "shoot-dns-service", Primary: ptr.To(false)},
+			},
+		},
+	}
+
+	var (
+		desc = getGardenMetricsDefinitions()[metricGardenControllerRegistrationResource]
+		ch   = make(chan prometheus.Metric, 2)
+	)
+	generateControllerRegistrationMetrics([]*gardenv1beta1.ControllerRegistration{controllerRegistration}, desc, testScrapeFailures, ch)
+
+	if len(ch) != 2 {
+		t.Fatalf("expected a metric per resource, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "provider-aws", "Infrastructure", "aws", "true")
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "provider-aws", "Extension", "shoot-dns-service", "false")
+	assert(t, <-ch, expected)
+}
+
+func Test_generateControllerInstallationMetrics(t *testing.T) {
+	controllerInstallation := &gardenv1beta1.ControllerInstallation{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider-aws-abcde"},
+		Spec: gardenv1beta1.ControllerInstallationSpec{
+			RegistrationRef: corev1.ObjectReference{Name: "provider-aws"},
+			SeedRef:         &corev1.ObjectReference{Name: "test-seed"},
+		},
+		Status: gardenv1beta1.ControllerInstallationStatus{
+			Conditions: []gardenv1beta1.Condition{
+				{Type: gardenv1beta1.ControllerInstallationInstalled, Status: gardenv1beta1.ConditionTrue},
+				{Type: gardenv1beta1.ControllerInstallationHealthy, Status: gardenv1beta1.ConditionFalse},
+				{Type: gardenv1beta1.ControllerInstallationProgressing, Status: gardenv1beta1.ConditionProgressing},
+				{Type: gardenv1beta1.ControllerInstallationValid, Status: gardenv1beta1.ConditionUnknown},
+				{Status: gardenv1beta1.ConditionTrue},
+			},
+		},
+	}
+
+	var (
+		desc = getGardenMetricsDefinitions()[metricGardenControllerInstallationCondition]
+		ch   = make(chan prometheus.Metric, 5)
+	)
+	generateControllerInstallationMetrics([]*gardenv1beta1.ControllerInstallation{controllerInstallation}, desc, testScrapeFailures, ch)
+
+	if len(ch) != 4 {
+		t.Fatalf("expected a metric per condition with a type, got %d", len(ch))
+	}
+	for _, condition := range []struct {
+		conditionType gardenv1beta1.ConditionType
+		value         float64
+	}{
+		{gardenv1beta1.ControllerInstallationInstalled, 1},
+		{gardenv1beta1.ControllerInstallationHealthy, 0},
+		{gardenv1beta1.ControllerInstallationProgressing, 2},
+		{gardenv1beta1.ControllerInstallationValid, -1},
+	} {
+		expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, condition.value, "provider-aws-abcde", "provider-aws", "test-seed", "", string(condition.conditionType))
+		assert(t, <-ch, expected)
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricFilter decides by the metric name whether a metric is exposed.
+type MetricFilter struct {
+	// Allow is the list of metric names which are exposed. An empty list allows all metrics.
+	Allow []string
+	// Deny is the list of metric names which are not exposed. It takes precedence over Allow.
+	Deny []string
+}
+
+func (f MetricFilter) allowed(name string) bool {
+	if len(f.Allow) > 0 && !slices.Contains(f.Allow, name) {
+		return false
+	}
+	return !slices.Contains(f.Deny, name)
+}
+
+// metricNames returns the sorted names of all metrics which can be exposed by the gardenMetricsCollector. They
+// have to match the names.Metrics which are used to validate the configuration.
+func metricNames() []string {
+	var metrics []string
+	for name := range getGardenMetricsDefinitions() {
+		metrics = append(metrics, name)
+	}
+	for _, template := range shootCustomizationMetrics {
+		metrics = append(metrics, template.Name)
+	}
+	metrics = append(metrics, shootOperationMetricNames...)
+	metrics = append(metrics, metricShootsCustomPrefix)
+	slices.Sort(metrics)
+	return metrics
+}
+
+// descNames returns the metric names by their descs. The Desc type does not expose the name, so it is recorded
+// when the descs are created.
+func descNames(descs map[string]*prometheus.Desc) map[*prometheus.Desc]string {
+	names := make(map[*prometheus.Desc]string, len(descs))
+	for name, desc := range descs {
+		names[desc] = name
+	}
+	return names
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+)
+
+func TestMetricFilter_allowed(t *testing.T) {
+	cases := []struct {
+		name     string
+		filter   MetricFilter
+		metric   string
+		expected bool
+	}{
+		{"empty filter", MetricFilter{}, metricGardenShootInfo, true},
+		{"allowed", MetricFilter{Allow: []string{metricGardenShootInfo}}, metricGardenShootInfo, true},
+		{"not in allowlist", MetricFilter{Allow: []string{metricGardenSeedInfo}}, metricGardenShootInfo, false},
+		{"denied", MetricFilter{Deny: []string{metricGardenShootInfo}}, metricGardenShootInfo, false},
+		{"deny takes precedence", MetricFilter{Allow: []string{metricGardenShootInfo}, Deny: []string{metricGardenShootInfo}}, metricGardenShootInfo, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.filter.allowed(tc.metric); got != tc.expected {
+			t.Errorf("%s: got %t, want %t", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func Test_descNames(t *testing.T) {
+	descs := getGardenMetricsDefinitions()
+	got := descNames(descs)
+	if len(got) != len(descs) {
+		t.Fatalf("expected a name per desc, got %d names for %d descs", len(got), len(descs))
+	}
+	for name, desc := range descs {
+		assert(t, got[desc], name)
+	}
+}
+
+func Test_metricNames_matchValidatedNames(t *testing.T) {
+	assert(t, metricNames(), names.Metrics)
+}
@@ -5,24 +5,43 @@
 package metrics
 
 import (
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 )
 
 // collectGardenletMetrics collects Gardenlet metrics.
-func (c gardenMetricsCollector) collectGardenletMetrics(ch chan<- prometheus.Metric) {
+func (c *gardenMetricsCollector) collectGardenletMetrics(ch chan<- prometheus.Metric) {
 	gardenlets, err := c.gardenletInformer.Lister().Gardenlets(metav1.NamespaceAll).List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
 		return
 	}
 
-	generateGardenletMetrics(gardenlets, c.descs, ch)
+	// The Seed of a Gardenlet has the name of the Gardenlet. Whether it exists is unknown if the Seeds cannot be listed.
+	var seedNames sets.Set[string]
+	if seeds, err := c.seedInformer.Lister().List(labels.Everything()); err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+	} else {
+		seedNames = sets.New[string]()
+		for _, seed := range seeds {
+			seedNames.Insert(seed.Name)
+		}
+	}
+
+	pendingSince := c.gardenletRollouts.update(gardenlets, time.Now())
+	generateGardenletMetrics(gardenlets, seedNames, pendingSince, c.descs, c.scrapeFailures, ch)
 }
 
-func generateGardenletMetrics(gardenlets []*v1alpha1.Gardenlet, descs map[string]*prometheus.Desc, ch chan<- prometheus.Metric) {
+func generateGardenletMetrics(gardenlets []*v1alpha1.Gardenlet, seedNames sets.Set[string], pendingSince map[types.UID]time.Time, descs map[string]*prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
 	for _, gardenlet := range gardenlets {
 		// Export a metric for each condition of the Gardenlet.
 		for _, condition := range gardenlet.Status.Conditions {
@@ -35,11 +54,32 @@ func generateGardenletMetrics(gardenlets []*v1alpha1.Gardenlet, descs map[string
 				mapConditionStatus(condition.Status),
 				[]string{
 					gardenlet.Name,
+					gardenlet.Namespace,
+					string(condition.Type),
+				}...,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+				continue
+			}
+			ch <- metric
+
+			if condition.LastTransitionTime.IsZero() {
+				continue
+			}
+			metric, err = prometheus.NewConstMetric(
+				descs[metricGardenGardenletConditionLastTransition],
+				prometheus.GaugeValue,
+				float64(condition.LastTransitionTime.Unix()),
+				[]string{
+					gardenlet.Name,
+					gardenlet.Namespace,
 					string(condition.Type),
+					string(condition.Status),
 				}...,
 			)
 			if err != nil {
-				ScrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+				scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
 				continue
 			}
 			ch <- metric
@@ -50,9 +90,10 @@ func generateGardenletMetrics(gardenlets []*v1alpha1.Gardenlet, descs map[string
 			prometheus.CounterValue,
 			float64(gardenlet.GetGeneration()),
 			gardenlet.Name,
+			gardenlet.Namespace,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+			scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
 		} else {
 			ch <- metric
 		}
@@ -62,11 +103,149 @@ func generateGardenletMetrics(gardenlets []*v1alpha1.Gardenlet, descs map[string
 			prometheus.CounterValue,
 			float64(gardenlet.Status.ObservedGeneration),
 			gardenlet.Name,
+			gardenlet.Namespace,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+		} else {
+			ch <- metric
+		}
+
+		image, version := gardenletImage(gardenlet)
+		metric, err = prometheus.NewConstMetric(
+			descs[metricGardenGardenletInfo],
+			prometheus.GaugeValue,
+			0,
+			gardenlet.Name,
+			gardenlet.Namespace,
+			image,
+			version,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+		} else {
+			ch <- metric
+		}
+
+		if seedNames != nil {
+			var seedExists float64
+			if seedNames.Has(gardenlet.Name) {
+				seedExists = 1
+			}
+			metric, err = prometheus.NewConstMetric(
+				descs[metricGardenGardenletSeedExists],
+				prometheus.GaugeValue,
+				seedExists,
+				gardenlet.Name,
+				gardenlet.Namespace,
+			)
+			if err != nil {
+				scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+			} else {
+				ch <- metric
+			}
+		}
+
+		var rolloutPending float64
+		if gardenletRolloutPending(gardenlet) {
+			rolloutPending = 1
+		}
+		metric, err = prometheus.NewConstMetric(
+			descs[metricGardenGardenletRolloutPending],
+			prometheus.GaugeValue,
+			rolloutPending,
+			gardenlet.Name,
+			gardenlet.Namespace,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+			scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
 		} else {
 			ch <- metric
 		}
+
+		since, ok := pendingSince[gardenlet.UID]
+		if !ok {
+			continue
+		}
+		metric, err = prometheus.NewConstMetric(
+			descs[metricGardenGardenletRolloutPendingSince],
+			prometheus.GaugeValue,
+			float64(since.Unix()),
+			gardenlet.Name,
+			gardenlet.Namespace,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// gardenletRolloutPending reports whether the gardenlet has not yet rolled out the current generation of its spec.
+func gardenletRolloutPending(gardenlet *v1alpha1.Gardenlet) bool {
+	return gardenlet.Generation != gardenlet.Status.ObservedGeneration
+}
+
+// gardenletImage returns the image repository and the version the Gardenlet deploys. The version is the tag of the
+// image, or the tag of the Helm chart if the image is defaulted by the chart.
+func gardenletImage(gardenlet *v1alpha1.Gardenlet) (string, string) {
+	var repository, version string
+	if image := gardenlet.Spec.Deployment.Image; image != nil {
+		repository = ptr.Deref(image.Repository, "")
+		version = ptr.Deref(image.Tag, "")
+	}
+	if version != "" {
+		return repository, version
+	}
+
+	ociRepository := gardenlet.Spec.Deployment.Helm.OCIRepository
+	if ociRepository.Tag != nil {
+		return repository, *ociRepository.Tag
+	}
+	if ref := ptr.Deref(ociRepository.Ref, ""); ref != "" {
+		ref, _, _ = strings.Cut(ref, "@")
+		if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+			version = ref[i+1:]
+		}
+	}
+	return repository, version
+}
+
+// gardenletRollouts remembers since when the rollouts of the Gardenlets are pending, by Gardenlet UID. As the
+// Gardenlet does not report it, the start of a pending rollout is the first refresh which observed it. Rollouts
+// which were already pending when the exporter started are pending since the first refresh.
+type gardenletRollouts struct {
+	mu    sync.Mutex
+	since map[types.UID]time.Time
+}
+
+func newGardenletRollouts() *gardenletRollouts {
+	return &gardenletRollouts{since: make(map[types.UID]time.Time)}
+}
+
+// update records the pending rollouts of the Gardenlets and forgets about the others. It returns since when the
+// rollouts of the Gardenlets are pending.
+func (r *gardenletRollouts) update(gardenlets []*v1alpha1.Gardenlet, now time.Time) map[types.UID]time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	since := make(map[types.UID]time.Time)
+	for _, gardenlet := range gardenlets {
+		if !gardenletRolloutPending(gardenlet) {
+			continue
+		}
+		if start, ok := r.since[gardenlet.UID]; ok {
+			since[gardenlet.UID] = start
+		} else {
+			since[gardenlet.UID] = now
+		}
+	}
+	r.since = since
+
+	result := make(map[types.UID]time.Time, len(since))
+	for uid, start := range since {
+		result[uid] = start
 	}
+	return result
 }
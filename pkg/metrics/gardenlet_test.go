@@ -3,6 +3,7 @@ package metrics
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -10,6 +11,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 )
 
@@ -53,6 +56,7 @@ func setupGardenletExpectations(gardenlet *seedv1alpha1.Gardenlet, descs map[str
 			mapConditionStatus(gardenlet.Status.Conditions[0].Status),
 			[]string{
 				gardenlet.Name,
+				gardenlet.Namespace,
 				string(gardenlet.Status.Conditions[0].Type),
 			}...,
 		)
@@ -66,6 +70,7 @@ func setupGardenletExpectations(gardenlet *seedv1alpha1.Gardenlet, descs map[str
 		prometheus.CounterValue,
 		float64(gardenlet.Generation),
 		gardenlet.Name,
+		gardenlet.Namespace,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not create expected generation metric: %v", err)
@@ -76,11 +81,47 @@ func setupGardenletExpectations(gardenlet *seedv1alpha1.Gardenlet, descs map[str
 		prometheus.CounterValue,
 		float64(gardenlet.Status.ObservedGeneration),
 		gardenlet.Name,
+		gardenlet.Namespace,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not create expected observed generation metric: %v", err)
 	}
 	expectations = append(expectations, expectedObservedGenerationMetric)
+	expectedInfoMetric, err := prometheus.NewConstMetric(
+		descs[metricGardenGardenletInfo],
+		prometheus.GaugeValue,
+		0,
+		gardenlet.Name,
+		gardenlet.Namespace,
+		"",
+		"",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create expected info metric: %v", err)
+	}
+	expectations = append(expectations, expectedInfoMetric)
+	expectedSeedExistsMetric, err := prometheus.NewConstMetric(
+		descs[metricGardenGardenletSeedExists],
+		prometheus.GaugeValue,
+		1,
+		gardenlet.Name,
+		gardenlet.Namespace,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create expected seed exists metric: %v", err)
+	}
+	expectations = append(expectations, expectedSeedExistsMetric)
+	expectedRolloutPendingMetric, err := prometheus.NewConstMetric(
+		descs[metricGardenGardenletRolloutPending],
+		prometheus.GaugeValue,
+		0,
+		gardenlet.Name,
+		gardenlet.Namespace,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create expected rollout pending metric: %v", err)
+	}
+	expectations = append(expectations, expectedRolloutPendingMetric)
 
 	return expectations, nil
 }
@@ -101,8 +142,8 @@ func TestGenerateGardenletMetrics(t *testing.T) {
 
 	descs := getGardenMetricsDefinitions()
 
-	ch := make(chan prometheus.Metric, 3)
-	generateGardenletMetrics(gardenlets, descs, ch)
+	ch := make(chan prometheus.Metric, 6)
+	generateGardenletMetrics(gardenlets, sets.New("test"), nil, descs, testScrapeFailures, ch)
 	close(ch)
 
 	expectations, err := setupGardenletExpectations(gardenlet, descs)
@@ -131,8 +172,8 @@ func TestGenerateGardenletMetricsWithNoStatusConditions(t *testing.T) {
 
 	descs := getGardenMetricsDefinitions()
 
-	ch := make(chan prometheus.Metric, 3)
-	generateGardenletMetrics(gardenlets, descs, ch)
+	ch := make(chan prometheus.Metric, 6)
+	generateGardenletMetrics(gardenlets, sets.New("test"), nil, descs, testScrapeFailures, ch)
 	close(ch)
 
 	expectations, err := setupGardenletExpectations(gardenlet, descs)
@@ -184,12 +225,12 @@ func TestGardenletMetricsGenerationWithNoGenerations(t *testing.T) {
 
 	descs := getGardenMetricsDefinitions()
 
-	ch := make(chan prometheus.Metric, 3)
-	generateGardenletMetrics(gardenlets, descs, ch)
+	ch := make(chan prometheus.Metric, 6)
+	generateGardenletMetrics(gardenlets, sets.New("test"), nil, descs, testScrapeFailures, ch)
 	close(ch)
 
 	scrapeFailuresCh := make(chan prometheus.Metric, 1)
-	ScrapeFailures.With(prometheus.Labels{"kind": "gardenlets"}).Collect(scrapeFailuresCh)
+	ScrapeFailures.With(prometheus.Labels{"kind": "gardenlets", "landscape": ""}).Collect(scrapeFailuresCh)
 	scrapeFailuresMetric := <-scrapeFailuresCh
 
 	dtoMetric := &dto.Metric{}
@@ -225,3 +266,55 @@ func TestGardenletMetricsGenerationWithNoGenerations(t *testing.T) {
 		metrics_count += 1
 	}
 }
+
+func TestGenerateGardenletMetricsWithPendingRollout(t *testing.T) {
+	gardenlet := setupGardenletResource(11, 10, nil)
+	gardenlet.UID = "uid"
+	gardenlet.Spec.Deployment.Image = &seedv1alpha1.Image{Repository: ptr.To("europe-docker.pkg.dev/gardener-project/releases/gardener/gardenlet")}
+	gardenlet.Spec.Deployment.Helm.OCIRepository.Ref = ptr.To("europe-docker.pkg.dev/gardener-project/releases/charts/gardener/gardenlet:v1.110.0")
+
+	var (
+		descs = getGardenMetricsDefinitions()
+		since = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		ch    = make(chan prometheus.Metric, 6)
+	)
+	generateGardenletMetrics([]*seedv1alpha1.Gardenlet{gardenlet}, sets.New[string](), map[types.UID]time.Time{"uid": since}, descs, testScrapeFailures, ch)
+
+	if len(ch) != 6 {
+		t.Fatalf("expected 6 metrics, got %d", len(ch))
+	}
+	<-ch
+	<-ch
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenGardenletInfo], prometheus.GaugeValue, 0,
+		"test", "test", "europe-docker.pkg.dev/gardener-project/releases/gardener/gardenlet", "v1.110.0",
+	)
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenGardenletSeedExists], prometheus.GaugeValue, 0, "test", "test")
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenGardenletRolloutPending], prometheus.GaugeValue, 1, "test", "test")
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenGardenletRolloutPendingSince], prometheus.GaugeValue, float64(since.Unix()), "test", "test")
+	assert(t, <-ch, expected)
+}
+
+func TestGardenletRolloutsUpdate(t *testing.T) {
+	var (
+		rollouts  = newGardenletRollouts()
+		gardenlet = setupGardenletResource(2, 1, nil)
+		first     = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		second    = first.Add(time.Minute)
+	)
+	gardenlet.UID = "uid"
+
+	assert(t, rollouts.update([]*seedv1alpha1.Gardenlet{gardenlet}, first), map[types.UID]time.Time{"uid": first})
+
+	// A rollout stays pending since it was observed first, even if the generation changes again.
+	gardenlet.Generation = 3
+	assert(t, rollouts.update([]*seedv1alpha1.Gardenlet{gardenlet}, second), map[types.UID]time.Time{"uid": first})
+
+	gardenlet.Status.ObservedGeneration = 3
+	assert(t, rollouts.update([]*seedv1alpha1.Gardenlet{gardenlet}, second), map[types.UID]time.Time{})
+
+	gardenlet.Generation = 4
+	assert(t, rollouts.update([]*seedv1alpha1.Gardenlet{gardenlet}, second), map[types.UID]time.Time{"uid": second})
+}
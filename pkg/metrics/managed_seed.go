@@ -12,18 +12,18 @@ import (
 )
 
 // collectManagedSeedMetrics collect managed seed metrics.
-func (c gardenMetricsCollector) collectManagedSeedMetrics(ch chan<- prometheus.Metric) {
+func (c *gardenMetricsCollector) collectManagedSeedMetrics(ch chan<- prometheus.Metric) {
 
 	managedSeeds, err := c.managedSeedInformer.Lister().ManagedSeeds(metav1.NamespaceAll).List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "managedSeeds"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "managedSeeds"}).Inc()
 		return
 	}
 
-	generateManagedSeedInfoMetrics(managedSeeds, c.descs[metricGardenManagedSeedInfo], ch)
+	generateManagedSeedInfoMetrics(managedSeeds, c.descs[metricGardenManagedSeedInfo], c.scrapeFailures, ch)
 }
 
-func generateManagedSeedInfoMetrics(managedSeeds []*v1alpha1.ManagedSeed, desc *prometheus.Desc, ch chan<- prometheus.Metric) {
+func generateManagedSeedInfoMetrics(managedSeeds []*v1alpha1.ManagedSeed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
 	for _, ms := range managedSeeds {
 		// Some sanity checks.
 		if ms == nil || ms.Spec.Shoot == nil {
@@ -42,7 +42,7 @@ func generateManagedSeedInfoMetrics(managedSeeds []*v1alpha1.ManagedSeed, desc *
 		)
 
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "managedSeeds"}).Inc()
+			scrapeFailures.With(prometheus.Labels{"kind": "managedSeeds"}).Inc()
 			return
 		}
 
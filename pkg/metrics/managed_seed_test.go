@@ -47,7 +47,7 @@ func Test_generateManagedSeedInfoMetrics(t *testing.T) {
 
 	ch := make(chan prometheus.Metric, 1)
 
-	generateManagedSeedInfoMetrics(managedSeeds, desc, ch)
+	generateManagedSeedInfoMetrics(managedSeeds, desc, testScrapeFailures, ch)
 
 	metric := <-ch
 
@@ -5,15 +5,35 @@
 package metrics
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	gardencoreinformerfactory "github.com/gardener/gardener/pkg/client/core/informers/externalversions"
 	gardencoreinformers "github.com/gardener/gardener/pkg/client/core/informers/externalversions/core/v1beta1"
+	gardensecurityinformerfactory "github.com/gardener/gardener/pkg/client/security/informers/externalversions"
 	gardensecurityinformers "github.com/gardener/gardener/pkg/client/security/informers/externalversions/security/v1alpha1"
+	gardenseedmanagementinformerfactory "github.com/gardener/gardener/pkg/client/seedmanagement/informers/externalversions"
 	gardenseedmanagementinformers "github.com/gardener/gardener/pkg/client/seedmanagement/informers/externalversions/seedmanagement/v1alpha1"
 
+	"github.com/gardener/gardener-metrics-exporter/pkg/health"
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+	"github.com/gardener/gardener-metrics-exporter/pkg/template"
+	"github.com/gardener/gardener-metrics-exporter/pkg/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 )
 
 func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
+	return newGardenMetricsDefinitions(nil)
+}
+
+// newGardenMetricsDefinitions returns the descs of the Garden metrics with the given constant labels.
+func newGardenMetricsDefinitions(constLabels prometheus.Labels) map[string]*prometheus.Desc {
 	return map[string]*prometheus.Desc{
 		metricGardenManagedSeedInfo: prometheus.NewDesc(
 			metricGardenManagedSeedInfo,
@@ -22,7 +42,235 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"name",
 				"shoot",
 			},
-			nil,
+			constLabels,
+		),
+
+		metricGardenBackupBucketInfo: prometheus.NewDesc(
+			metricGardenBackupBucketInfo,
+			"Information about a BackupBucket.",
+			[]string{
+				"name",
+				"iaas",
+				"region",
+				"seed",
+			},
+			constLabels,
+		),
+
+		metricGardenBackupBucketOperationState: prometheus.NewDesc(
+			metricGardenBackupBucketOperationState,
+			"Operation state of a BackupBucket. Possible values: 1=Succeeded|2=Processing|3=Pending|4=Aborted|5=Error|6=Failed. Available operations: 'Create'|'Reconcile'|'Delete'|'Restore'|'Migrate'.",
+			[]string{
+				"name",
+				"operation",
+			},
+			constLabels,
+		),
+
+		metricGardenBackupBucketLastError: prometheus.NewDesc(
+			metricGardenBackupBucketLastError,
+			"Last error of a BackupBucket per error code. The value is the timestamp in seconds the error was reported last.",
+			[]string{
+				"name",
+				"code",
+				"classification",
+			},
+			constLabels,
+		),
+
+		metricGardenBackupEntryInfo: prometheus.NewDesc(
+			metricGardenBackupEntryInfo,
+			"Information about a BackupEntry.",
+			[]string{
+				"name",
+				"namespace",
+				"bucket",
+				"iaas",
+				"region",
+				"seed",
+			},
+			constLabels,
+		),
+
+		metricGardenBackupEntryOperationState: prometheus.NewDesc(
+			metricGardenBackupEntryOperationState,
+			"Operation state of a BackupEntry. Possible values: 1=Succeeded|2=Processing|3=Pending|4=Aborted|5=Error|6=Failed. Available operations: 'Create'|'Reconcile'|'Delete'|'Restore'|'Migrate'.",
+			[]string{
+				"name",
+				"namespace",
+				"operation",
+			},
+			constLabels,
+		),
+
+		metricGardenBackupEntryLastError: prometheus.NewDesc(
+			metricGardenBackupEntryLastError,
+			"Last error of a BackupEntry per error code. The value is the timestamp in seconds the error was reported last.",
+			[]string{
+				"name",
+				"namespace",
+				"code",
+				"classification",
+			},
+			constLabels,
+		),
+
+		metricGardenBackupEntryOrphaned: prometheus.NewDesc(
+			metricGardenBackupEntryOrphaned,
+			"Whether the Shoot of a BackupEntry does not exist anymore. Possible values: 0=Shoot exists|1=Orphaned",
+			[]string{
+				"name",
+				"namespace",
+				"shoot",
+			},
+			constLabels,
+		),
+
+		metricGardenControllerRegistrationResource: prometheus.NewDesc(
+			metricGardenControllerRegistrationResource,
+			"Resource provided by the extension of a ControllerRegistration.",
+			[]string{
+				"name",
+				"kind",
+				"type",
+				"primary",
+			},
+			constLabels,
+		),
+
+		metricGardenControllerInstallationCondition: prometheus.NewDesc(
+			metricGardenControllerInstallationCondition,
+			"Condition state of a ControllerInstallation. Possible values: -1=Unknown|0=Unhealthy|1=Healthy|2=Progressing",
+			[]string{
+				"name",
+				"registration",
+				"seed",
+				"shoot",
+				"condition",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileKubernetesVersionInfo: prometheus.NewDesc(
+			metricGardenCloudProfileKubernetesVersionInfo,
+			"Information about a Kubernetes version offered by a CloudProfile. Possible classifications: 'unavailable'|'preview'|'supported'|'deprecated'|'expired'.",
+			[]string{
+				"name",
+				"iaas",
+				"version",
+				"classification",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileKubernetesVersionExpiration: prometheus.NewDesc(
+			metricGardenCloudProfileKubernetesVersionExpiration,
+			"Timestamp in seconds at which a Kubernetes version of a CloudProfile expires.",
+			[]string{
+				"name",
+				"iaas",
+				"version",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileMachineImageVersionInfo: prometheus.NewDesc(
+			metricGardenCloudProfileMachineImageVersionInfo,
+			"Information about a machine image version offered by a CloudProfile. Possible classifications: 'unavailable'|'preview'|'supported'|'deprecated'|'expired'.",
+			[]string{
+				"name",
+				"iaas",
+				"image",
+				"version",
+				"classification",
+				"architectures",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileMachineImageVersionExpiration: prometheus.NewDesc(
+			metricGardenCloudProfileMachineImageVersionExpiration,
+			"Timestamp in seconds at which a machine image version of a CloudProfile expires.",
+			[]string{
+				"name",
+				"iaas",
+				"image",
+				"version",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileMachineTypeInfo: prometheus.NewDesc(
+			metricGardenCloudProfileMachineTypeInfo,
+			"Information about a machine type offered by a CloudProfile.",
+			[]string{
+				"name",
+				"iaas",
+				"machine_type",
+				"architecture",
+				"usable",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileMachineTypeCapacity: prometheus.NewDesc(
+			metricGardenCloudProfileMachineTypeCapacity,
+			"Capacity of a machine type offered by a CloudProfile. Available resources: 'cpu'|'gpu'|'memory' (bytes).",
+			[]string{
+				"name",
+				"iaas",
+				"machine_type",
+				"resource",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileVolumeTypeInfo: prometheus.NewDesc(
+			metricGardenCloudProfileVolumeTypeInfo,
+			"Information about a volume type offered by a CloudProfile.",
+			[]string{
+				"name",
+				"iaas",
+				"volume_type",
+				"class",
+				"usable",
+			},
+			constLabels,
+		),
+
+		metricGardenCloudProfileRegionInfo: prometheus.NewDesc(
+			metricGardenCloudProfileRegionInfo,
+			"Information about a region offered by a CloudProfile and its zones.",
+			[]string{
+				"name",
+				"iaas",
+				"region",
+				"zones",
+			},
+			constLabels,
+		),
+
+		metricGardenNamespacedCloudProfileInfo: prometheus.NewDesc(
+			metricGardenNamespacedCloudProfileInfo,
+			"Information about a NamespacedCloudProfile, the project it belongs to and the CloudProfile it extends.",
+			[]string{
+				"name",
+				"project",
+				"parent",
+			},
+			constLabels,
+		),
+
+		metricGardenNamespacedCloudProfileOverrides: prometheus.NewDesc(
+			metricGardenNamespacedCloudProfileOverrides,
+			"Count of entries a NamespacedCloudProfile overrides or adds to its parent CloudProfile. Available kinds: 'kubernetes_versions'|'machine_image_versions'|'machine_types'|'volume_types'.",
+			[]string{
+				"name",
+				"project",
+				"parent",
+				"kind",
+			},
+			constLabels,
 		),
 
 		metricGardenOperationsTotal: prometheus.NewDesc(
@@ -36,7 +284,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"version",
 				"region",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenProjectsStatus: prometheus.NewDesc(
@@ -46,7 +294,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"name",
 				"phase",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenSeedCondition: prometheus.NewDesc(
@@ -58,7 +306,18 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"iaas",
 				"region",
 			},
-			nil,
+			constLabels,
+		),
+
+		metricGardenSeedConditionLastTransition: prometheus.NewDesc(
+			metricGardenSeedConditionLastTransition,
+			"Timestamp in seconds of the last status transition of a Seed condition.",
+			[]string{
+				"name",
+				"condition",
+				"status",
+			},
+			constLabels,
 		),
 
 		metricGardenSeedInfo: prometheus.NewDesc(
@@ -73,7 +332,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"protected",
 				"version",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenSeedCapacity: prometheus.NewDesc(
@@ -88,7 +347,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"protected",
 				"resource",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenSeedUsage: prometheus.NewDesc(
@@ -103,7 +362,137 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"protected",
 				"resource",
 			},
-			nil,
+			constLabels,
+		),
+
+		metricGardenSeedAllocatable: prometheus.NewDesc(
+			metricGardenSeedAllocatable,
+			"Seed resources available for scheduling.",
+			[]string{
+				"name",
+				"namespace",
+				"iaas",
+				"region",
+				"visible",
+				"protected",
+				"resource",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedHeadroom: prometheus.NewDesc(
+			metricGardenSeedHeadroom,
+			"Allocatable Seed resources which are not used by hosted Shoots.",
+			[]string{
+				"name",
+				"namespace",
+				"iaas",
+				"region",
+				"visible",
+				"protected",
+				"resource",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedShootUsage: prometheus.NewDesc(
+			metricGardenSeedShootUsage,
+			"Count of Shoots hosted by a Seed per purpose and hibernation state.",
+			[]string{
+				"name",
+				"iaas",
+				"region",
+				"purpose",
+				"hibernated",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedTaint: prometheus.NewDesc(
+			metricGardenSeedTaint,
+			"Taint of a Seed with its value.",
+			[]string{
+				"name",
+				"key",
+				"value",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedSetting: prometheus.NewDesc(
+			metricGardenSeedSetting,
+			"Setting of a Seed with its value. Settings are named by their JSON path, e.g. 'scheduling.visible'.",
+			[]string{
+				"name",
+				"setting",
+				"value",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedZone: prometheus.NewDesc(
+			metricGardenSeedZone,
+			"Availability zone a Seed is deployed to.",
+			[]string{
+				"name",
+				"region",
+				"zone",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedBackupInfo: prometheus.NewDesc(
+			metricGardenSeedBackupInfo,
+			"Backup configuration of a Seed. Seeds without backup have no series.",
+			[]string{
+				"name",
+				"provider",
+				"region",
+				"credentials_kind",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedIngressInfo: prometheus.NewDesc(
+			metricGardenSeedIngressInfo,
+			"Ingress configuration of a Seed.",
+			[]string{
+				"name",
+				"domain",
+				"controller",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedDNSInfo: prometheus.NewDesc(
+			metricGardenSeedDNSInfo,
+			"DNS provider of a Seed.",
+			[]string{
+				"name",
+				"provider",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedGardenerVersionInfo: prometheus.NewDesc(
+			metricGardenSeedGardenerVersionInfo,
+			"Version of the gardenlet which manages a Seed.",
+			[]string{
+				"name",
+				"version",
+			},
+			constLabels,
+		),
+
+		metricGardenSeedGardenerVersionSkew: prometheus.NewDesc(
+			metricGardenSeedGardenerVersionSkew,
+			"Count of minor versions the gardenlet of a Seed is behind the newest gardenlet version of all Seeds.",
+			[]string{
+				"name",
+				"version",
+				"latest_version",
+			},
+			constLabels,
 		),
 
 		metricGardenSeedOperationState: prometheus.NewDesc(
@@ -113,7 +502,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"name",
 				"operation",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenGardenletCondition: prometheus.NewDesc(
@@ -121,9 +510,22 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 			"Condition state of a Gardenlet. Possible values: -1=Unknown|0=Unhealthy|1=Healthy|2=Progressing",
 			[]string{
 				"name",
+				"namespace",
 				"condition",
 			},
-			nil,
+			constLabels,
+		),
+
+		metricGardenGardenletConditionLastTransition: prometheus.NewDesc(
+			metricGardenGardenletConditionLastTransition,
+			"Timestamp in seconds of the last status transition of a Gardenlet condition.",
+			[]string{
+				"name",
+				"namespace",
+				"condition",
+				"status",
+			},
+			constLabels,
 		),
 
 		metricGardenGardenletGeneration: prometheus.NewDesc(
@@ -131,8 +533,9 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 			"Generation of a Gardenlet.",
 			[]string{
 				"name",
+				"namespace",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenGardenletObservedGeneration: prometheus.NewDesc(
@@ -140,8 +543,51 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 			"Observed generation of a Gardenlet.",
 			[]string{
 				"name",
+				"namespace",
+			},
+			constLabels,
+		),
+
+		metricGardenGardenletInfo: prometheus.NewDesc(
+			metricGardenGardenletInfo,
+			"Information about a Gardenlet with the image and version it deploys.",
+			[]string{
+				"name",
+				"namespace",
+				"image",
+				"version",
+			},
+			constLabels,
+		),
+
+		metricGardenGardenletSeedExists: prometheus.NewDesc(
+			metricGardenGardenletSeedExists,
+			"Whether the Seed managed by a Gardenlet exists. Possible values: 0=Missing|1=Exists",
+			[]string{
+				"name",
+				"namespace",
+			},
+			constLabels,
+		),
+
+		metricGardenGardenletRolloutPending: prometheus.NewDesc(
+			metricGardenGardenletRolloutPending,
+			"Whether the generation of a Gardenlet differs from its observed generation. Possible values: 0=Rolled out|1=Pending",
+			[]string{
+				"name",
+				"namespace",
 			},
-			nil,
+			constLabels,
+		),
+
+		metricGardenGardenletRolloutPendingSince: prometheus.NewDesc(
+			metricGardenGardenletRolloutPendingSince,
+			"Timestamp in seconds since when the rollout of a Gardenlet is pending, as first observed by the exporter.",
+			[]string{
+				"name",
+				"namespace",
+			},
+			constLabels,
 		),
 
 		metricGardenShootCondition: prometheus.NewDesc(
@@ -161,9 +607,75 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"uid",
 				"technical_id",
 				"has_user_errors",
+				"error_classification",
 				"is_compliant",
 			},
-			nil,
+			constLabels,
+		),
+
+		metricGardenShootConditionLastTransition: prometheus.NewDesc(
+			metricGardenShootConditionLastTransition,
+			"Timestamp in seconds of the last status transition of a Shoot condition.",
+			[]string{
+				"name",
+				"project",
+				"condition",
+				"status",
+				"technical_id",
+			},
+			constLabels,
+		),
+
+		metricGardenShootLastError: prometheus.NewDesc(
+			metricGardenShootLastError,
+			"Last errors of a Shoot per error code. The value is the timestamp in seconds the error was reported last.",
+			[]string{
+				"name",
+				"project",
+				"code",
+				"classification",
+				"task_id",
+				"technical_id",
+			},
+			constLabels,
+		),
+
+		metricGardenShootErrorsTotal: prometheus.NewDesc(
+			metricGardenShootErrorsTotal,
+			"Count of Shoots with an error code.",
+			[]string{
+				"code",
+				"classification",
+				"iaas",
+				"seed",
+			},
+			constLabels,
+		),
+
+		metricGardenShootVersionExpiration: prometheus.NewDesc(
+			metricGardenShootVersionExpiration,
+			"Timestamp in seconds at which a Kubernetes or machine image version of a Shoot or its worker pool expires according to its CloudProfile. Available components: 'kubernetes'|'machine_image'.",
+			[]string{
+				"name",
+				"project",
+				"worker_pool",
+				"component",
+				"image",
+				"version",
+				"classification",
+				"technical_id",
+			},
+			constLabels,
+		),
+
+		metricGardenShootDeprecatedVersionsTotal: prometheus.NewDesc(
+			metricGardenShootDeprecatedVersionsTotal,
+			"Count of Shoots which run a deprecated Kubernetes or machine image version. Available components: 'kubernetes'|'machine_image'.",
+			[]string{
+				"project",
+				"component",
+			},
+			constLabels,
 		),
 
 		metricGardenShootCreation: prometheus.NewDesc(
@@ -175,7 +687,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"uid",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootHibernated: prometheus.NewDesc(
@@ -187,7 +699,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"uid",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootInfo: prometheus.NewDesc(
@@ -214,7 +726,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"is_hibernated",
 				"status",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootNodeMaxTotal: prometheus.NewDesc(
@@ -225,7 +737,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"project",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootNodeMinTotal: prometheus.NewDesc(
@@ -236,7 +748,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"project",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootWorkerNodeMaxTotal: prometheus.NewDesc(
@@ -249,7 +761,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"worker_machine_type",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootWorkerNodeMinTotal: prometheus.NewDesc(
@@ -262,7 +774,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"worker_machine_type",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootNodeInfo: prometheus.NewDesc(
@@ -279,7 +791,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"architecture",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootOperationProgressPercent: prometheus.NewDesc(
@@ -291,7 +803,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"operation",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenShootOperationState: prometheus.NewDesc(
@@ -302,7 +814,7 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 				"operation",
 				"technical_id",
 			},
-			nil,
+			constLabels,
 		),
 
 		metricGardenUsersSum: prometheus.NewDesc(
@@ -311,54 +823,423 @@ func getGardenMetricsDefinitions() map[string]*prometheus.Desc {
 			[]string{
 				"kind",
 			},
-			nil,
+			constLabels,
 		),
 	}
 }
 
+// CollectorOptions configures how the gardenMetricsCollector runs its collectors.
+type CollectorOptions struct {
+	// Workers is the maximum number of collectors which run in parallel.
+	Workers int
+	// Timeout is the maximum duration a single collector may take before its metrics are dropped.
+	Timeout time.Duration
+	// RefreshInterval is the minimum duration between two refreshes of the metrics snapshot.
+	RefreshInterval time.Duration
+	// DisabledCollectors is the list of collectors which do not run. Informers which are only required by
+	// disabled collectors are not started.
+	DisabledCollectors []string
+	// Metrics filters the exposed metrics by their name.
+	Metrics MetricFilter
+	// Labels configures how the values of metric labels are determined.
+	Labels LabelOptions
+	// Health tracks the sync and watch state of the informers if set.
+	Health *health.Tracker
+}
+
+// LabelOptions configures how the values of metric labels are determined.
+type LabelOptions struct {
+	// CostObjectAnnotation is the Project annotation which holds the cost object of a Shoot.
+	CostObjectAnnotation string
+	// CostObjectTypeAnnotation is the Project annotation which holds the cost object type of a Shoot.
+	CostObjectTypeAnnotation string
+	// ErrorCodeClasses overrides the default classes of Shoot error codes, see names.ErrorClasses.
+	ErrorCodeClasses map[string]string
+}
+
+// InformerFactories holds the factories to create the informers for the Garden resources.
+type InformerFactories struct {
+	Core           gardencoreinformerfactory.SharedInformerFactory
+	SeedManagement gardenseedmanagementinformerfactory.SharedInformerFactory
+	Security       gardensecurityinformerfactory.SharedInformerFactory
+}
+
 type gardenMetricsCollector struct {
-	managedSeedInformer        gardenseedmanagementinformers.ManagedSeedInformer
-	gardenletInformer          gardenseedmanagementinformers.GardenletInformer
-	shootInformer              gardencoreinformers.ShootInformer
-	seedInformer               gardencoreinformers.SeedInformer
-	projectInformer            gardencoreinformers.ProjectInformer
-	secretBindingInformer      gardencoreinformers.SecretBindingInformer
-	credentialsBindingInformer gardensecurityinformers.CredentialsBindingInformer
-	descs                      map[string]*prometheus.Desc
-	logger                     *logrus.Logger
+	managedSeedInformer            gardenseedmanagementinformers.ManagedSeedInformer
+	gardenletInformer              gardenseedmanagementinformers.GardenletInformer
+	gardenletRollouts              *gardenletRollouts
+	shootInformer                  gardencoreinformers.ShootInformer
+	seedInformer                   gardencoreinformers.SeedInformer
+	projectInformer                gardencoreinformers.ProjectInformer
+	secretBindingInformer          gardencoreinformers.SecretBindingInformer
+	credentialsBindingInformer     gardensecurityinformers.CredentialsBindingInformer
+	cloudProfileInformer           gardencoreinformers.CloudProfileInformer
+	namespacedCloudProfileInformer gardencoreinformers.NamespacedCloudProfileInformer
+	backupBucketInformer           gardencoreinformers.BackupBucketInformer
+	backupEntryInformer            gardencoreinformers.BackupEntryInformer
+	controllerRegistrationInformer gardencoreinformers.ControllerRegistrationInformer
+	controllerInstallationInformer gardencoreinformers.ControllerInstallationInformer
+	descs                          map[string]*prometheus.Desc
+	customizationMetrics           []*template.MetricTemplate
+	shootOperations                *shootOperationMetrics
+	scrapeFailures                 *prometheus.CounterVec
+	metricFilter                   MetricFilter
+	labelOptions                   LabelOptions
+	errorClassifier                errorClassifier
+	snapshot                       *snapshot
+	logger                         *logrus.Logger
+}
+
+// namedCollector is a collector function with a name, which is used to report failures. The resources are the
+// kinds of Garden objects the collector reads. A change of one of them invalidates the collector's metrics.
+// If resync is set, the metrics are also invalidated in this interval, as they depend on the current time.
+// Collectors with objects expose their metrics per object of the first resource instead of using collect.
+type namedCollector struct {
+	name      string
+	resources []string
+	resync    time.Duration
+	collect   func(ch chan<- prometheus.Metric)
+	objects   *objectCollector
+}
+
+// lifecycleResync is the interval in which the collectors exposing the lifecycle classification of versions are
+// refreshed. The classification changes over time without an update of the CloudProfile, e.g. once a version expires.
+const lifecycleResync = time.Minute
+
+// collectorResult holds the metrics of a single collector run. Done is closed once the collector returned, which
+// is later than the result is available in case the collector timed out.
+type collectorResult struct {
+	metrics  []prometheus.Metric
+	timedOut bool
+	done     <-chan struct{}
+}
+
+// collectors returns the collectors of the gardenMetricsCollector in the order in which their metrics are exposed.
+func (c *gardenMetricsCollector) collectors() []namedCollector {
+	return []namedCollector{
+		{
+			name:      names.CollectorManagedSeeds,
+			resources: []string{resourceManagedSeeds},
+			collect:   c.collectManagedSeedMetrics,
+		},
+		{
+			name:      names.CollectorGardenlets,
+			resources: []string{resourceGardenlets, resourceSeeds},
+			collect:   c.collectGardenletMetrics,
+		},
+		{
+			name:      names.CollectorProjects,
+			resources: []string{resourceProjects},
+			collect:   c.collectProjectMetrics,
+		},
+		{
+			name:      names.CollectorShoots,
+			resources: []string{resourceShoots, resourceProjects, resourceManagedSeeds, resourceSeeds, resourceSecretBindings, resourceCredentialsBindings, resourceCloudProfiles, resourceNamespacedCloudProfiles},
+			resync:    lifecycleResync,
+			objects:   c.shootObjects(),
+		},
+		{
+			name:      names.CollectorShootCustomization,
+			resources: []string{resourceShoots},
+			collect:   c.collectShootCustomizationMetrics,
+		},
+		{
+			name:      names.CollectorSeeds,
+			resources: []string{resourceSeeds, resourceShoots},
+			collect:   c.collectSeedMetrics,
+		},
+		{
+			name:      names.CollectorCloudProfiles,
+			resources: []string{resourceCloudProfiles},
+			resync:    lifecycleResync,
+			collect:   c.collectCloudProfileMetrics,
+		},
+		{
+			name:      names.CollectorNamespacedCloudProfiles,
+			resources: []string{resourceNamespacedCloudProfiles, resourceProjects},
+			collect:   c.collectNamespacedCloudProfileMetrics,
+		},
+		{
+			name:      names.CollectorBackupBuckets,
+			resources: []string{resourceBackupBuckets},
+			collect:   c.collectBackupBucketMetrics,
+		},
+		{
+			name:      names.CollectorBackupEntries,
+			resources: []string{resourceBackupEntries, resourceBackupBuckets, resourceShoots},
+			collect:   c.collectBackupEntryMetrics,
+		},
+		{
+			name:      names.CollectorControllerRegistrations,
+			resources: []string{resourceControllerRegistrations},
+			collect:   c.collectControllerRegistrationMetrics,
+		},
+		{
+			name:      names.CollectorControllerInstallations,
+			resources: []string{resourceControllerInstallations},
+			collect:   c.collectControllerInstallationMetrics,
+		},
+	}
+}
+
+// enabledCollectors returns the collectors which are not disabled.
+func (c *gardenMetricsCollector) enabledCollectors(disabled []string) []namedCollector {
+	var collectors []namedCollector
+	for _, collector := range c.collectors() {
+		if slices.Contains(disabled, collector.name) {
+			continue
+		}
+		collectors = append(collectors, collector)
+	}
+	return collectors
+}
+
+// setupInformers creates the informers for the given resources and returns them by resource.
+func (c *gardenMetricsCollector) setupInformers(factories InformerFactories, resources sets.Set[string]) map[string]cache.SharedIndexInformer {
+	informers := make(map[string]cache.SharedIndexInformer)
+	if resources.Has(resourceShoots) {
+		c.shootInformer = factories.Core.Core().V1beta1().Shoots()
+		informers[resourceShoots] = c.shootInformer.Informer()
+	}
+	if resources.Has(resourceSeeds) {
+		c.seedInformer = factories.Core.Core().V1beta1().Seeds()
+		informers[resourceSeeds] = c.seedInformer.Informer()
+	}
+	if resources.Has(resourceProjects) {
+		c.projectInformer = factories.Core.Core().V1beta1().Projects()
+		informers[resourceProjects] = c.projectInformer.Informer()
+	}
+	if resources.Has(resourceSecretBindings) {
+		c.secretBindingInformer = factories.Core.Core().V1beta1().SecretBindings()
+		informers[resourceSecretBindings] = c.secretBindingInformer.Informer()
+	}
+	if resources.Has(resourceCloudProfiles) {
+		c.cloudProfileInformer = factories.Core.Core().V1beta1().CloudProfiles()
+		informers[resourceCloudProfiles] = c.cloudProfileInformer.Informer()
+	}
+	if resources.Has(resourceNamespacedCloudProfiles) {
+		c.namespacedCloudProfileInformer = factories.Core.Core().V1beta1().NamespacedCloudProfiles()
+		informers[resourceNamespacedCloudProfiles] = c.namespacedCloudProfileInformer.Informer()
+	}
+	if resources.Has(resourceBackupBuckets) {
+		c.backupBucketInformer = factories.Core.Core().V1beta1().BackupBuckets()
+		informers[resourceBackupBuckets] = c.backupBucketInformer.Informer()
+	}
+	if resources.Has(resourceBackupEntries) {
+		c.backupEntryInformer = factories.Core.Core().V1beta1().BackupEntries()
+		informers[resourceBackupEntries] = c.backupEntryInformer.Informer()
+	}
+	if resources.Has(resourceControllerRegistrations) {
+		c.controllerRegistrationInformer = factories.Core.Core().V1beta1().ControllerRegistrations()
+		informers[resourceControllerRegistrations] = c.controllerRegistrationInformer.Informer()
+	}
+	if resources.Has(resourceControllerInstallations) {
+		c.controllerInstallationInformer = factories.Core.Core().V1beta1().ControllerInstallations()
+		informers[resourceControllerInstallations] = c.controllerInstallationInformer.Informer()
+	}
+	if resources.Has(resourceManagedSeeds) {
+		c.managedSeedInformer = factories.SeedManagement.Seedmanagement().V1alpha1().ManagedSeeds()
+		informers[resourceManagedSeeds] = c.managedSeedInformer.Informer()
+	}
+	if resources.Has(resourceGardenlets) {
+		c.gardenletInformer = factories.SeedManagement.Seedmanagement().V1alpha1().Gardenlets()
+		informers[resourceGardenlets] = c.gardenletInformer.Informer()
+	}
+	if resources.Has(resourceCredentialsBindings) {
+		c.credentialsBindingInformer = factories.Security.Security().V1alpha1().CredentialsBindings()
+		informers[resourceCredentialsBindings] = c.credentialsBindingInformer.Informer()
+	}
+	return informers
 }
 
 // Describe implements the prometheus.Describe interface, which intends the gardenMetricsCollector to be a Prometheus collector.
 func (c *gardenMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
-	for _, desc := range c.descs {
+	for name, desc := range c.descs {
+		if !c.metricFilter.allowed(name) {
+			continue
+		}
 		ch <- desc
 	}
-	registerShootCustomizationMetrics(ch)
+	registerShootCustomizationMetrics(c.customizationMetrics, ch)
+	if c.shootOperations != nil {
+		c.shootOperations.describe(c.metricFilter, ch)
+	}
 }
 
 // Collect implements the prometheus.Collect interface, which intends the gardenMetricsCollector to be a Prometheus collector.
-// TODO Can we run the collectors in parallel?
+// It exposes the current metrics snapshot, which is kept up to date by informer events in the background.
+// Only the leader exposes the metrics.
 func (c *gardenMetricsCollector) Collect(ch chan<- prometheus.Metric) {
-	c.collectManagedSeedMetrics(ch)
-	c.collectGardenletMetrics(ch)
-	c.collectProjectMetrics(ch)
-	c.collectShootMetrics(ch)
-	c.collectSeedMetrics(ch)
+	if !leading.Load() {
+		return
+	}
+	c.snapshot.collect(ch)
+	if c.shootOperations != nil {
+		c.shootOperations.collect(c.metricFilter, ch)
+	}
+}
+
+// runCollectors executes the given collectors and returns their results in the same order as the collectors. A
+// collector occupies one of the workers until its collect function returns, also if it timed out before. Hence, at
+// most cap(workers) collectors run at the same time, also across subsequent calls which share the workers.
+func runCollectors(collectors []namedCollector, workers chan struct{}, timeout time.Duration, landscape string, logger *logrus.Logger) []collectorResult {
+	var (
+		results = make([]collectorResult, len(collectors))
+		wg      sync.WaitGroup
+	)
+
+	for i, collector := range collectors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			results[i] = runCollector(collector, timeout, func() { <-workers }, landscape, logger)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runCollector executes a single collector and buffers its metrics. In case the collector does not finish
+// within the timeout its metrics are dropped and a scrape failure is counted. The collector keeps running in
+// the background until it is done, but its metrics are discarded. The release function is called and the done
+// channel of the result is closed once the collector returned.
+func runCollector(collector namedCollector, timeout time.Duration, release func(), landscape string, logger *logrus.Logger) collectorResult {
+	var (
+		metricsCh = make(chan prometheus.Metric)
+		resultCh  = make(chan []prometheus.Metric, 1)
+		done      = make(chan struct{})
+	)
+
+	go func() {
+		var metrics []prometheus.Metric
+		for metric := range metricsCh {
+			metrics = append(metrics, metric)
+		}
+		resultCh <- metrics
+	}()
+
+	go func() {
+		start := time.Now()
+		defer close(done)
+		defer release()
+		defer close(metricsCh)
+		defer func() {
+			CollectionDuration.WithLabelValues(collector.name, landscape).Observe(time.Since(start).Seconds())
+			if r := recover(); r != nil {
+				logger.Errorf("Collector %s panicked: %v", collector.name, r)
+				ScrapeFailures.WithLabelValues(collector.name, landscape).Inc()
+			}
+		}()
+		collector.collect(metricsCh)
+	}()
+
+	if timeout <= 0 {
+		return collectorResult{metrics: <-resultCh, done: done}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case metrics := <-resultCh:
+		return collectorResult{metrics: metrics, done: done}
+	case <-timer.C:
+		logger.Warnf("Collector %s did not finish within %s, dropping its metrics.", collector.name, timeout)
+		ScrapeFailures.WithLabelValues(collector.name+"-timeout", landscape).Inc()
+		return collectorResult{timedOut: true, done: done}
+	}
+}
+
+// SetupMetricsCollector creates the informers required by the enabled collectors and returns the metrics collector
+// for the Garden cluster of the given landscape. All its metrics carry the landscape as label, unless the landscape
+// is empty. The informer event handlers keep a snapshot of the metrics up to date until the context is cancelled.
+// The informer factories need to be started afterwards.
+func SetupMetricsCollector(ctx context.Context, landscape string, factories InformerFactories, options CollectorOptions, logger *logrus.Logger) (prometheus.Collector, error) {
+	var constLabels prometheus.Labels
+	if landscape != "" {
+		constLabels = prometheus.Labels{"landscape": landscape}
+	}
+
+	metricsCollector := &gardenMetricsCollector{
+		descs:                newGardenMetricsDefinitions(constLabels),
+		customizationMetrics: newShootCustomizationMetrics(constLabels),
+		scrapeFailures:       ScrapeFailures.MustCurryWith(landscapeLabel(landscape)),
+		metricFilter:         options.Metrics,
+		labelOptions:         options.Labels,
+		errorClassifier:      newErrorClassifier(options.Labels.ErrorCodeClasses),
+		gardenletRollouts:    newGardenletRollouts(),
+		logger:               logger,
+	}
+
+	collectors := metricsCollector.enabledCollectors(options.DisabledCollectors)
+	resources := sets.New[string]()
+	for _, collector := range collectors {
+		resources.Insert(collector.resources...)
+	}
+
+	metricsCollector.snapshot = newSnapshot(collectors, descNames(metricsCollector.descs), options, landscape, logger)
+	informers := metricsCollector.setupInformers(factories, resources)
+	for resource, informer := range informers {
+		metricsCollector.snapshot.synced[resource] = informer.HasSynced
+		if _, err := informer.AddEventHandler(metricsCollector.snapshot.eventHandler(resource)); err != nil {
+			return nil, fmt.Errorf("could not register event handler for %s: %w", resource, err)
+		}
+		if options.Health != nil {
+			if err := options.Health.Track(landscape, resource, informer); err != nil {
+				return nil, fmt.Errorf("could not track health of informer for %s: %w", resource, err)
+			}
+		}
+	}
+	for resource, informer := range informers {
+		go metricsCollector.waitForSync(ctx, landscape, resource, informer)
+	}
+
+	// The Shoot operations are recorded from the Shoot updates, if the Shoot metrics are enabled.
+	if !slices.Contains(options.DisabledCollectors, names.CollectorShoots) {
+		metricsCollector.shootOperations = newShootOperationMetrics(constLabels)
+		if _, err := informers[resourceShoots].AddEventHandler(metricsCollector.shootOperations.eventHandler()); err != nil {
+			return nil, fmt.Errorf("could not register event handler for shoot operations: %w", err)
+		}
+	}
+	go metricsCollector.snapshot.run(ctx)
+
+	return metricsCollector, nil
 }
 
-// SetupMetricsCollector takes informers to configure the metrics collectors.
-func SetupMetricsCollector(shootInformer gardencoreinformers.ShootInformer, seedInformer gardencoreinformers.SeedInformer, projectInformer gardencoreinformers.ProjectInformer, managedSeedInformer gardenseedmanagementinformers.ManagedSeedInformer, gardenletInformer gardenseedmanagementinformers.GardenletInformer, secretBindingInformer gardencoreinformers.SecretBindingInformer, credentialsBindingInformer gardensecurityinformers.CredentialsBindingInformer, logger *logrus.Logger) {
-	metricsCollector := gardenMetricsCollector{
-		managedSeedInformer:        managedSeedInformer,
-		gardenletInformer:          gardenletInformer,
-		shootInformer:              shootInformer,
-		seedInformer:               seedInformer,
-		projectInformer:            projectInformer,
-		secretBindingInformer:      secretBindingInformer,
-		credentialsBindingInformer: credentialsBindingInformer,
-		descs:                      getGardenMetricsDefinitions(),
-		logger:                     logger,
+// waitForSync reports the sync state of the informer and refreshes the collectors depending on its resource
+// once it has synced.
+func (c *gardenMetricsCollector) waitForSync(ctx context.Context, landscape, resource string, informer cache.SharedIndexInformer) {
+	start := time.Now()
+	synced := InformerSynced.WithLabelValues(resource, landscape)
+	synced.Set(0)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return
 	}
-	prometheus.MustRegister(&metricsCollector)
+
+	duration := time.Since(start)
+	synced.Set(1)
+	InformerSyncDuration.WithLabelValues(resource, landscape).Set(duration.Seconds())
+	c.logger.Infof("Informer for %s synced after %s.", resource, duration.Round(time.Millisecond))
+	c.snapshot.invalidate(resource)
+}
+
+// landscapeLabel returns the labels to curry the exporter metrics of the collector for the landscape.
+func landscapeLabel(landscape string) prometheus.Labels {
+	return prometheus.Labels{"landscape": landscape}
+}
+
+// RegisterExporterMetrics registers the metrics about the exporter itself.
+func RegisterExporterMetrics() {
 	prometheus.MustRegister(ScrapeFailures)
+	prometheus.MustRegister(InformerSynced)
+	prometheus.MustRegister(InformerSyncDuration)
+	prometheus.MustRegister(CollectorSkipped)
+	prometheus.MustRegister(CollectionDuration)
+	prometheus.MustRegister(MetricSeries)
+	prometheus.MustRegister(InformerEvents)
+	prometheus.MustRegister(BuildInfo)
+	prometheus.MustRegister(Leader)
+
+	info := version.Get()
+	BuildInfo.WithLabelValues(info.GitVersion, info.GitCommit, info.BuildDate, info.GoVersion).Set(1)
 }
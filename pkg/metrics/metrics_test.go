@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestCollector(name string, value float64, delay time.Duration) namedCollector {
+	desc := prometheus.NewDesc(name, "Test metric.", nil, nil)
+	return namedCollector{
+		name: name,
+		collect: func(ch chan<- prometheus.Metric) {
+			time.Sleep(delay)
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+		},
+	}
+}
+
+func Test_runCollectors_keepsCollectorOrder(t *testing.T) {
+	collectors := []namedCollector{
+		newTestCollector("first", 1, 30*time.Millisecond),
+		newTestCollector("second", 2, 0),
+		newTestCollector("third", 3, 10*time.Millisecond),
+	}
+
+	results := runCollectors(collectors, make(chan struct{}, 3), time.Second, "", logrus.New())
+
+	if len(results) != len(collectors) {
+		t.Fatalf("expected %d results, got %d", len(collectors), len(results))
+	}
+	for i, collector := range collectors {
+		if len(results[i].metrics) != 1 {
+			t.Fatalf("expected 1 metric for collector %s, got %d", collector.name, len(results[i].metrics))
+		}
+		expected := prometheus.MustNewConstMetric(prometheus.NewDesc(collector.name, "Test metric.", nil, nil), prometheus.GaugeValue, float64(i+1))
+		if results[i].metrics[0].Desc().String() != expected.Desc().String() {
+			t.Errorf("Got %s\nwant %s", results[i].metrics[0].Desc(), expected.Desc())
+		}
+	}
+}
+
+func Test_runCollectors_dropsTimedOutCollector(t *testing.T) {
+	collectors := []namedCollector{
+		newTestCollector("fast", 1, 0),
+		newTestCollector("slow", 2, time.Second),
+	}
+
+	results := runCollectors(collectors, make(chan struct{}, 1), 50*time.Millisecond, "", logrus.New())
+
+	if len(results[0].metrics) != 1 {
+		t.Errorf("expected 1 metric for the fast collector, got %d", len(results[0].metrics))
+	}
+	if !results[1].timedOut || results[1].metrics != nil {
+		t.Errorf("expected the slow collector to time out without metrics, got %d", len(results[1].metrics))
+	}
+}
+
+func Test_runCollectors_timedOutCollectorKeepsWorker(t *testing.T) {
+	workers := make(chan struct{}, 1)
+	results := runCollectors([]namedCollector{newTestCollector("slow", 1, 200*time.Millisecond)}, workers, 20*time.Millisecond, "", logrus.New())
+
+	if !results[0].timedOut {
+		t.Fatal("expected the collector to time out")
+	}
+	if len(workers) != 1 {
+		t.Errorf("expected the timed out collector to occupy its worker, got %d occupied workers", len(workers))
+	}
+	<-results[0].done
+	if len(workers) != 0 {
+		t.Errorf("expected the worker to be released once the collector returned, got %d occupied workers", len(workers))
+	}
+}
+
+func Test_gardenMetricsCollector_registersPerLandscape(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	for _, landscape := range []string{"dev", "live"} {
+		constLabels := prometheus.Labels{"landscape": landscape}
+		collector := &gardenMetricsCollector{
+			descs:                newGardenMetricsDefinitions(constLabels),
+			customizationMetrics: newShootCustomizationMetrics(constLabels),
+		}
+		if err := registry.Register(collector); err != nil {
+			t.Fatalf("expected the collector of landscape %s to register, got %v", landscape, err)
+		}
+	}
+
+	desc := newGardenMetricsDefinitions(prometheus.Labels{"landscape": "dev"})[metricGardenShootInfo]
+	if !strings.Contains(desc.String(), `constLabels: {landscape="dev"}`) {
+		t.Errorf("expected the landscape label in %s", desc)
+	}
+}
+
+// testScrapeFailures is the scrape failure counter of a collector for an unnamed landscape.
+var testScrapeFailures = ScrapeFailures.MustCurryWith(prometheus.Labels{"landscape": ""})
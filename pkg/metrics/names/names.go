@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package names contains the names of the collectors, metrics and Shoot error classes of the metrics exporter.
+// It does not depend on the collectors, so that the configuration can be validated without importing them.
+package names
+
+// Names of the collectors, which can be disabled individually.
+const (
+	CollectorShoots                  = "shoots"
+	CollectorShootCustomization      = "customization"
+	CollectorSeeds                   = "seeds"
+	CollectorProjects                = "projects"
+	CollectorGardenlets              = "gardenlets"
+	CollectorManagedSeeds            = "managedseeds"
+	CollectorCloudProfiles           = "cloudprofiles"
+	CollectorNamespacedCloudProfiles = "namespacedcloudprofiles"
+	CollectorBackupBuckets           = "backupbuckets"
+	CollectorBackupEntries           = "backupentries"
+	CollectorControllerRegistrations = "controllerregistrations"
+	CollectorControllerInstallations = "controllerinstallations"
+)
+
+// CollectorNames contains the names of all collectors.
+var CollectorNames = []string{
+	CollectorShoots,
+	CollectorShootCustomization,
+	CollectorSeeds,
+	CollectorProjects,
+	CollectorGardenlets,
+	CollectorManagedSeeds,
+	CollectorCloudProfiles,
+	CollectorNamespacedCloudProfiles,
+	CollectorBackupBuckets,
+	CollectorBackupEntries,
+	CollectorControllerRegistrations,
+	CollectorControllerInstallations,
+}
+
+// Classes of Shoot error codes.
+const (
+	// ErrorClassUser is the class of errors which are caused by the user, e.g. by missing permissions.
+	ErrorClassUser = "user"
+	// ErrorClassInfrastructure is the class of errors which are caused by the infrastructure provider.
+	ErrorClassInfrastructure = "infrastructure"
+	// ErrorClassGardener is the class of errors which are caused by Gardener.
+	ErrorClassGardener = "gardener"
+	// ErrorClassUnknown is the class of errors without a classified error code.
+	ErrorClassUnknown = "unknown"
+)
+
+// ErrorClasses contains all classes of Shoot error codes.
+var ErrorClasses = []string{
+	ErrorClassUser,
+	ErrorClassInfrastructure,
+	ErrorClassGardener,
+	ErrorClassUnknown,
+}
+
+// Metrics contains the sorted names of all metrics which can be exposed by the metrics exporter.
+var Metrics = []string{
+	"garden_backupbucket_info",
+	"garden_backupbucket_last_error",
+	"garden_backupbucket_operation_states",
+	"garden_backupentry_info",
+	"garden_backupentry_last_error",
+	"garden_backupentry_operation_states",
+	"garden_backupentry_orphaned",
+	"garden_cloudprofile_kubernetes_version_expiration_timestamp_seconds",
+	"garden_cloudprofile_kubernetes_version_info",
+	"garden_cloudprofile_machine_image_version_expiration_timestamp_seconds",
+	"garden_cloudprofile_machine_image_version_info",
+	"garden_cloudprofile_machine_type_capacity",
+	"garden_cloudprofile_machine_type_info",
+	"garden_cloudprofile_region_info",
+	"garden_cloudprofile_volume_type_info",
+	"garden_controllerinstallation_condition",
+	"garden_controllerregistration_resource_info",
+	"garden_gardenlet_condition",
+	"garden_gardenlet_condition_last_transition_timestamp_seconds",
+	"garden_gardenlet_generation_total",
+	"garden_gardenlet_info",
+	"garden_gardenlet_observed_generation_total",
+	"garden_gardenlet_rollout_pending",
+	"garden_gardenlet_rollout_pending_since_timestamp_seconds",
+	"garden_gardenlet_seed_exists",
+	"garden_managed_seed_info",
+	"garden_namespaced_cloudprofile_info",
+	"garden_namespaced_cloudprofile_overrides",
+	"garden_projects_status",
+	"garden_seed_allocatable",
+	"garden_seed_backup_info",
+	"garden_seed_capacity",
+	"garden_seed_condition",
+	"garden_seed_condition_last_transition_timestamp_seconds",
+	"garden_seed_dns_info",
+	"garden_seed_gardener_version_info",
+	"garden_seed_gardener_version_skew",
+	"garden_seed_headroom",
+	"garden_seed_info",
+	"garden_seed_ingress_info",
+	"garden_seed_operation_states",
+	"garden_seed_setting_info",
+	"garden_seed_shoot_usage",
+	"garden_seed_taint_info",
+	"garden_seed_usage",
+	"garden_seed_zone_info",
+	"garden_shoot_condition",
+	"garden_shoot_condition_last_transition_timestamp_seconds",
+	"garden_shoot_creation_timestamp",
+	"garden_shoot_deprecated_versions_total",
+	"garden_shoot_errors_total",
+	"garden_shoot_hibernated",
+	"garden_shoot_info",
+	"garden_shoot_last_error",
+	"garden_shoot_node_info",
+	"garden_shoot_node_max_total",
+	"garden_shoot_node_min_total",
+	"garden_shoot_operation_duration_seconds",
+	"garden_shoot_operation_progress_percent",
+	"garden_shoot_operation_states",
+	"garden_shoot_operation_transitions_total",
+	"garden_shoot_operations_total",
+	"garden_shoot_version_expiration_timestamp_seconds",
+	"garden_shoot_worker_node_max_total",
+	"garden_shoot_worker_node_min_total",
+	"garden_shoots_custom",
+	"garden_shoots_custom_addon_kubedashboard_total",
+	"garden_shoots_custom_addon_nginxingress_total",
+	"garden_shoots_custom_apiserver_admissionplugins_total",
+	"garden_shoots_custom_apiserver_auditpolicy_total",
+	"garden_shoots_custom_apiserver_featuregates_total",
+	"garden_shoots_custom_apiserver_oidcconfig_total",
+	"garden_shoots_custom_extensions_total",
+	"garden_shoots_custom_kcm_featuregates_total",
+	"garden_shoots_custom_kcm_horizontalpodautoscale_total",
+	"garden_shoots_custom_kcm_nodecidrmasksize_total",
+	"garden_shoots_custom_kubelet_podpidlimit_total",
+	"garden_shoots_custom_network_customdomain_total",
+	"garden_shoots_custom_proxy_mode_total",
+	"garden_shoots_custom_scheduler_featuregates_total",
+	"garden_shoots_custom_worker_annotations_total",
+	"garden_shoots_custom_worker_labels_total",
+	"garden_shoots_custom_worker_multiplepools_total",
+	"garden_shoots_custom_worker_multizones_total",
+	"garden_shoots_custom_worker_taints_total",
+	"garden_shoots_hibernation_enabled_total",
+	"garden_shoots_hibernation_schedule_total",
+	"garden_shoots_maintenance_autoupdate_imageversion_total",
+	"garden_shoots_maintenance_autoupdate_k8sversion_total",
+	"garden_shoots_maintenance_window_total",
+	"garden_users_total",
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"fmt"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	constantsv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	gardencorelisters "github.com/gardener/gardener/pkg/client/core/listers/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// collectNamespacedCloudProfileMetrics collects NamespacedCloudProfile metrics.
+func (c *gardenMetricsCollector) collectNamespacedCloudProfileMetrics(ch chan<- prometheus.Metric) {
+	namespacedCloudProfiles, err := c.namespacedCloudProfileInformer.Lister().NamespacedCloudProfiles(metav1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "namespacedcloudprofiles"}).Inc()
+		return
+	}
+
+	projects, err := c.projectInformer.Lister().List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "projects-count"}).Inc()
+		return
+	}
+
+	for _, namespacedCloudProfile := range namespacedCloudProfiles {
+		var projectName string
+		if project, err := findProject(projects, namespacedCloudProfile.Namespace); err == nil {
+			projectName = *project
+		}
+		generateNamespacedCloudProfileMetrics(namespacedCloudProfile, projectName, c.descs, c.scrapeFailures, ch)
+	}
+}
+
+func generateNamespacedCloudProfileMetrics(namespacedCloudProfile *gardenv1beta1.NamespacedCloudProfile, projectName string, descs map[string]*prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	metric, err := prometheus.NewConstMetric(
+		descs[metricGardenNamespacedCloudProfileInfo],
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			namespacedCloudProfile.Name,
+			projectName,
+			namespacedCloudProfile.Spec.Parent.Name,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "namespacedcloudprofiles"}).Inc()
+		return
+	}
+	ch <- metric
+
+	// Expose how many entries of the parent are overridden or added, per kind of entry.
+	var kubernetesVersions, machineImageVersions int
+	if namespacedCloudProfile.Spec.Kubernetes != nil {
+		kubernetesVersions = len(namespacedCloudProfile.Spec.Kubernetes.Versions)
+	}
+	for _, image := range namespacedCloudProfile.Spec.MachineImages {
+		machineImageVersions += len(image.Versions)
+	}
+	for _, override := range []struct {
+		kind  string
+		count int
+	}{
+		{"kubernetes_versions", kubernetesVersions},
+		{"machine_image_versions", machineImageVersions},
+		{"machine_types", len(namespacedCloudProfile.Spec.MachineTypes)},
+		{"volume_types", len(namespacedCloudProfile.Spec.VolumeTypes)},
+	} {
+		metric, err := prometheus.NewConstMetric(
+			descs[metricGardenNamespacedCloudProfileOverrides],
+			prometheus.GaugeValue,
+			float64(override.count),
+			[]string{
+				namespacedCloudProfile.Name,
+				projectName,
+				namespacedCloudProfile.Spec.Parent.Name,
+				override.kind,
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "namespacedcloudprofiles"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// cloudProfileResolver looks up the effective CloudProfile spec of Shoots. The effective spec of a
+// NamespacedCloudProfile is its parent CloudProfile merged with its overrides, which Gardener keeps in its status.
+type cloudProfileResolver struct {
+	cloudProfiles           gardencorelisters.CloudProfileLister
+	namespacedCloudProfiles gardencorelisters.NamespacedCloudProfileLister
+}
+
+func newCloudProfileResolver(cloudProfiles gardencorelisters.CloudProfileLister, namespacedCloudProfiles gardencorelisters.NamespacedCloudProfileLister) *cloudProfileResolver {
+	return &cloudProfileResolver{
+		cloudProfiles:           cloudProfiles,
+		namespacedCloudProfiles: namespacedCloudProfiles,
+	}
+}
+
+// resolve returns the effective spec of the CloudProfile or NamespacedCloudProfile the Shoot references. The spec
+// of a NamespacedCloudProfile is only known once Gardener merged its current generation into the status. The
+// returned spec must not be modified.
+func (r *cloudProfileResolver) resolve(shoot *gardenv1beta1.Shoot) (*gardenv1beta1.CloudProfileSpec, error) {
+	reference := shoot.Spec.CloudProfile
+	if reference == nil && shoot.Spec.CloudProfileName != nil { // nolint:staticcheck // SA1019: shoot.Spec.CloudProfileName is deprecated
+		reference = &gardenv1beta1.CloudProfileReference{
+			Kind: constantsv1beta1.CloudProfileReferenceKindCloudProfile,
+			Name: *shoot.Spec.CloudProfileName, // nolint:staticcheck // SA1019: shoot.Spec.CloudProfileName is deprecated
+		}
+	}
+	if reference == nil {
+		return nil, fmt.Errorf("shoot %s/%s does not reference a cloud profile", shoot.Namespace, shoot.Name)
+	}
+
+	if reference.Kind != constantsv1beta1.CloudProfileReferenceKindNamespacedCloudProfile {
+		cloudProfile, err := r.cloudProfiles.Get(reference.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &cloudProfile.Spec, nil
+	}
+
+	namespacedCloudProfile, err := r.namespacedCloudProfiles.NamespacedCloudProfiles(shoot.Namespace).Get(reference.Name)
+	if err != nil {
+		return nil, err
+	}
+	if namespacedCloudProfile.Status.ObservedGeneration != namespacedCloudProfile.Generation {
+		return nil, fmt.Errorf("status of namespaced cloud profile %s/%s is outdated", namespacedCloudProfile.Namespace, namespacedCloudProfile.Name)
+	}
+	return &namespacedCloudProfile.Status.CloudProfileSpec, nil
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	constantsv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	gardencorelisters "github.com/gardener/gardener/pkg/client/core/listers/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+func newTestCloudProfiles() (*gardenv1beta1.CloudProfile, *gardenv1beta1.NamespacedCloudProfile) {
+	expirationDate := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	extendedExpirationDate := metav1.NewTime(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	cloudProfile := &gardenv1beta1.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: gardenv1beta1.CloudProfileSpec{
+			Type: "aws",
+			Kubernetes: gardenv1beta1.KubernetesSettings{
+				Versions: []gardenv1beta1.ExpirableVersion{{Version: "1.31.1", ExpirationDate: &expirationDate}},
+			},
+			MachineImages: []gardenv1beta1.MachineImage{{
+				Name: "gardenlinux",
+				Versions: []gardenv1beta1.MachineImageVersion{
+					{ExpirableVersion: gardenv1beta1.ExpirableVersion{Version: "1592.1.0", ExpirationDate: &expirationDate}, Architectures: []string{"amd64"}},
+				},
+			}},
+			MachineTypes: []gardenv1beta1.MachineType{{Name: "m5.large"}},
+		},
+	}
+	namespacedCloudProfile := &gardenv1beta1.NamespacedCloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-extended", Namespace: "garden-test"},
+		Spec: gardenv1beta1.NamespacedCloudProfileSpec{
+			Parent: gardenv1beta1.CloudProfileReference{Kind: constantsv1beta1.CloudProfileReferenceKindCloudProfile, Name: "aws"},
+			Kubernetes: &gardenv1beta1.KubernetesSettings{
+				Versions: []gardenv1beta1.ExpirableVersion{
+					{Version: "1.31.1", ExpirationDate: &extendedExpirationDate},
+					{Version: "1.99.0"},
+				},
+			},
+			MachineImages: []gardenv1beta1.MachineImage{{
+				Name: "gardenlinux",
+				Versions: []gardenv1beta1.MachineImageVersion{
+					{ExpirableVersion: gardenv1beta1.ExpirableVersion{Version: "1592.1.0", ExpirationDate: &extendedExpirationDate}},
+					{ExpirableVersion: gardenv1beta1.ExpirableVersion{Version: "1592.2.0"}, Architectures: []string{"arm64"}},
+				},
+			}},
+			MachineTypes: []gardenv1beta1.MachineType{{Name: "m5.xlarge"}},
+		},
+	}
+	return cloudProfile, namespacedCloudProfile
+}
+
+func Test_cloudProfileResolver_resolve(t *testing.T) {
+	cloudProfile, namespacedCloudProfile := newTestCloudProfiles()
+	namespacedCloudProfile.Generation = 2
+	namespacedCloudProfile.Status.ObservedGeneration = 2
+	namespacedCloudProfile.Status.CloudProfileSpec = *cloudProfile.Spec.DeepCopy()
+	namespacedCloudProfile.Status.CloudProfileSpec.MachineTypes = append(namespacedCloudProfile.Status.CloudProfileSpec.MachineTypes, namespacedCloudProfile.Spec.MachineTypes...)
+	cloudProfileIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	namespacedCloudProfileIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := cloudProfileIndexer.Add(cloudProfile); err != nil {
+		t.Fatal(err)
+	}
+	if err := namespacedCloudProfileIndexer.Add(namespacedCloudProfile); err != nil {
+		t.Fatal(err)
+	}
+	resolver := newCloudProfileResolver(gardencorelisters.NewCloudProfileLister(cloudProfileIndexer), gardencorelisters.NewNamespacedCloudProfileLister(namespacedCloudProfileIndexer))
+
+	shoot := &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-shoot", Namespace: "garden-test"},
+		Spec:       gardenv1beta1.ShootSpec{CloudProfileName: ptr.To("aws")},
+	}
+	spec, err := resolver.resolve(shoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, spec, &cloudProfile.Spec)
+
+	shoot.Spec.CloudProfile = &gardenv1beta1.CloudProfileReference{Kind: constantsv1beta1.CloudProfileReferenceKindNamespacedCloudProfile, Name: "aws-extended"}
+	spec, err = resolver.resolve(shoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, spec, &namespacedCloudProfile.Status.CloudProfileSpec)
+
+	namespacedCloudProfile.Generation = 3
+	if _, err := resolver.resolve(shoot); err == nil {
+		t.Error("expected an error for a NamespacedCloudProfile with an outdated status")
+	}
+	namespacedCloudProfile.Generation = 2
+
+	shoot.Namespace = "garden-other"
+	if _, err := resolver.resolve(shoot); err == nil {
+		t.Error("expected an error for a NamespacedCloudProfile of another namespace")
+	}
+}
+
+func Test_generateNamespacedCloudProfileMetrics(t *testing.T) {
+	_, namespacedCloudProfile := newTestCloudProfiles()
+
+	var (
+		descs = getGardenMetricsDefinitions()
+		ch    = make(chan prometheus.Metric, 5)
+	)
+	generateNamespacedCloudProfileMetrics(namespacedCloudProfile, "test", descs, testScrapeFailures, ch)
+
+	if len(ch) != 5 {
+		t.Fatalf("expected an info metric and a metric per kind of override, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenNamespacedCloudProfileInfo], prometheus.GaugeValue, 0, "aws-extended", "test", "aws")
+	assert(t, <-ch, expected)
+	for _, override := range []struct {
+		kind  string
+		count float64
+	}{
+		{"kubernetes_versions", 2},
+		{"machine_image_versions", 2},
+		{"machine_types", 1},
+		{"volume_types", 0},
+	} {
+		expected, _ := prometheus.NewConstMetric(descs[metricGardenNamespacedCloudProfileOverrides], prometheus.GaugeValue, override.count, "aws-extended", "test", "aws", override.kind)
+		assert(t, <-ch, expected)
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// objectCollector computes the metrics of a collector per object of the collector's first resource. The snapshot
+// keeps the series of every object by its UID and recomputes them only on events of the object. Events of the
+// other resources and resyncs recompute the series of all objects.
+type objectCollector struct {
+	// list returns all objects of the resource.
+	list func() []interface{}
+	// prepare returns the function which computes the series of a single object. It is called once per refresh
+	// and looks up the state shared by all objects, e.g. the objects of the other resources.
+	prepare func() (collectObjectFunc, error)
+	// specResources are the resources of which the collector only reads the metadata and the spec. Updates of
+	// their status do not invalidate the series.
+	specResources []string
+}
+
+// collectObjectFunc sends the series of the object and adds its contributions to the aggregated metrics of the collector.
+type collectObjectFunc func(obj interface{}, ch chan<- prometheus.Metric, aggregated aggregation)
+
+// objectSeries are the series of a single object together with its contributions to the aggregated metrics.
+type objectSeries struct {
+	metrics    []prometheus.Metric
+	counts     map[string]int
+	aggregated aggregation
+}
+
+// aggregation sums up the contributions of objects to gauges, by the desc and the label values of the gauge.
+type aggregation map[aggregationKey]float64
+
+type aggregationKey struct {
+	desc *prometheus.Desc
+	// labels are the label values, each prefixed by the labelSeparator.
+	labels string
+}
+
+// labelSeparator separates the label values of an aggregated gauge. It is not part of a valid UTF-8 label value.
+const labelSeparator = "\xff"
+
+// add adds the value to the gauge with the given desc and label values.
+func (a aggregation) add(desc *prometheus.Desc, value float64, labelValues ...string) {
+	var labels strings.Builder
+	for _, labelValue := range labelValues {
+		labels.WriteString(labelSeparator)
+		labels.WriteString(labelValue)
+	}
+	a[aggregationKey{desc: desc, labels: labels.String()}] += value
+}
+
+// merge adds the values of the other aggregation multiplied by the factor. Gauges which drop to zero are removed,
+// as the aggregated metrics only expose the label combinations of existing objects.
+func (a aggregation) merge(other aggregation, factor float64) {
+	for key, value := range other {
+		a[key] += factor * value
+		if a[key] == 0 {
+			delete(a, key)
+		}
+	}
+}
+
+// metrics returns the gauges of the aggregation. The kind is used to report failures.
+func (a aggregation) metrics(kind, landscape string) []prometheus.Metric {
+	metrics := make([]prometheus.Metric, 0, len(a))
+	for key, value := range a {
+		metric, err := prometheus.NewConstMetric(
+			key.desc,
+			prometheus.GaugeValue,
+			value,
+			strings.Split(key.labels, labelSeparator)[1:]...,
+		)
+		if err != nil {
+			ScrapeFailures.WithLabelValues(kind, landscape).Inc()
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// objectUID returns the UID of an object, also of a deleted object whose final state is unknown.
+func objectUID(obj interface{}) (types.UID, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return "", false
+	}
+	return objMeta.GetUID(), true
+}
+
+// statusUpdate reports whether an update event only changes the status of an object, i.e. it keeps the
+// generation, the labels and the annotations.
+func statusUpdate(oldObj, newObj interface{}) bool {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return false
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return false
+	}
+	return oldMeta.GetGeneration() == newMeta.GetGeneration() &&
+		maps.Equal(oldMeta.GetLabels(), newMeta.GetLabels()) &&
+		maps.Equal(oldMeta.GetAnnotations(), newMeta.GetAnnotations())
+}
+
+// isSpecResource reports whether the collector only reads the metadata and the spec of the resource.
+func (c namedCollector) isSpecResource(resource string) bool {
+	return c.objects != nil && slices.Contains(c.objects.specResources, resource)
+}
@@ -23,10 +23,10 @@ func init() {
 }
 
 // collectProjectMetrics collect Project metrics.
-func (c gardenMetricsCollector) collectProjectMetrics(ch chan<- prometheus.Metric) {
+func (c *gardenMetricsCollector) collectProjectMetrics(ch chan<- prometheus.Metric) {
 	projects, err := c.projectInformer.Lister().List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "projects-count"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "projects-count"}).Inc()
 		return
 	}
 
@@ -52,7 +52,7 @@ func (c gardenMetricsCollector) collectProjectMetrics(ch chan<- prometheus.Metri
 			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "projects-status"}).Inc()
+			c.scrapeFailures.With(prometheus.Labels{"kind": "projects-status"}).Inc()
 			return
 		}
 		ch <- metric
@@ -97,21 +97,21 @@ func (c gardenMetricsCollector) collectProjectMetrics(ch chan<- prometheus.Metri
 
 	metric, err = prometheus.NewConstMetric(c.descs[metricGardenUsersSum], prometheus.GaugeValue, float64(len(users)), "users")
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "users-count"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "users-count"}).Inc()
 		return
 	}
 	ch <- metric
 
 	metric, err = prometheus.NewConstMetric(c.descs[metricGardenUsersSum], prometheus.GaugeValue, float64(len(groups)), "group")
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "users-count"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "users-count"}).Inc()
 		return
 	}
 	ch <- metric
 
 	metric, err = prometheus.NewConstMetric(c.descs[metricGardenUsersSum], prometheus.GaugeValue, float64(len(technicalUsers)), "technical")
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "users-count"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "users-count"}).Inc()
 		return
 	}
 	ch <- metric
@@ -11,6 +11,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	versionutil "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
 )
 
 var seedOperations = [5]string{
@@ -22,31 +24,38 @@ var seedOperations = [5]string{
 }
 
 // collectProjectMetrics collect Seed metrics.
-func (c gardenMetricsCollector) collectSeedMetrics(ch chan<- prometheus.Metric) {
+func (c *gardenMetricsCollector) collectSeedMetrics(ch chan<- prometheus.Metric) {
 	seeds, err := c.seedInformer.Lister().List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
 		return
 	}
 
 	// Fetch all Shoots.
 	shoots, err := c.shootInformer.Lister().Shoots(metav1.NamespaceAll).List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 		return
 	}
 
-	hostedShootCount := make(map[string]float64)
+	var (
+		hostedShootCount = make(map[string]float64)
+		shootUsage       = make(map[string]map[seedShootUsageKey]float64)
+	)
 	for _, shoot := range shoots {
 		if shoot.Spec.SeedName == nil {
 			continue
 		}
 		if _, ok := hostedShootCount[*shoot.Spec.SeedName]; !ok {
 			hostedShootCount[*shoot.Spec.SeedName] = 0
+			shootUsage[*shoot.Spec.SeedName] = make(map[seedShootUsageKey]float64)
 		}
 		hostedShootCount[*shoot.Spec.SeedName] = hostedShootCount[*shoot.Spec.SeedName] + 1
+		shootUsage[*shoot.Spec.SeedName][seedShootUsageKey{purpose: shootPurpose(shoot), hibernated: shoot.Status.IsHibernated}]++
 	}
 
+	latestGardenerVersion := latestSeedGardenerVersion(seeds)
+
 	for _, seed := range seeds {
 		var protected bool
 
@@ -57,7 +66,7 @@ func (c gardenMetricsCollector) collectSeedMetrics(ch chan<- prometheus.Metric)
 			}
 		}
 
-		visible := seed.Spec.Settings.Scheduling.Visible
+		visible := seed.Spec.Settings != nil && seed.Spec.Settings.Scheduling != nil && seed.Spec.Settings.Scheduling.Visible
 
 		metric, err := prometheus.NewConstMetric(
 			c.descs[metricGardenSeedInfo],
@@ -74,7 +83,7 @@ func (c gardenMetricsCollector) collectSeedMetrics(ch chan<- prometheus.Metric)
 			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+			c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 			continue
 		}
 		ch <- metric
@@ -95,7 +104,7 @@ func (c gardenMetricsCollector) collectSeedMetrics(ch chan<- prometheus.Metric)
 				}...,
 			)
 			if err != nil {
-				ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 				continue
 			}
 			ch <- metric
@@ -117,56 +126,372 @@ func (c gardenMetricsCollector) collectSeedMetrics(ch chan<- prometheus.Metric)
 				}...,
 			)
 			if err != nil {
-				ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
+
+		for kind, resource := range seed.Status.Allocatable {
+			metric, err = prometheus.NewConstMetric(
+				c.descs[metricGardenSeedAllocatable],
+				prometheus.GaugeValue,
+				float64(resource.Value()),
+				[]string{
+					seed.Name,
+					seed.Namespace,
+					seed.Spec.Provider.Type,
+					seed.Spec.Provider.Region,
+					strconv.FormatBool(visible),
+					strconv.FormatBool(protected),
+					kind.String(),
+				}...,
+			)
+			if err != nil {
+				c.scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
 				continue
 			}
 			ch <- metric
 		}
 
-		generateSeedConditionMetrics(seed, c.descs[metricGardenSeedCondition], ch)
-		generateSeedOperationStateMetrics(seed, c.descs[metricGardenSeedOperationState], ch)
+		generateSeedTaintMetrics(seed, c.descs[metricGardenSeedTaint], c.scrapeFailures, ch)
+		generateSeedSettingMetrics(seed, c.descs[metricGardenSeedSetting], c.scrapeFailures, ch)
+		generateSeedZoneMetrics(seed, c.descs[metricGardenSeedZone], c.scrapeFailures, ch)
+		generateSeedBackupMetrics(seed, c.descs[metricGardenSeedBackupInfo], c.scrapeFailures, ch)
+		generateSeedIngressMetrics(seed, c.descs[metricGardenSeedIngressInfo], c.scrapeFailures, ch)
+		generateSeedDNSMetrics(seed, c.descs[metricGardenSeedDNSInfo], c.scrapeFailures, ch)
+		generateSeedGardenerVersionMetrics(seed, latestGardenerVersion, c.descs, c.scrapeFailures, ch)
+		generateSeedHeadroomMetrics(seed, hostedShootCount[seed.Name], c.descs[metricGardenSeedHeadroom], c.scrapeFailures, ch, visible, protected)
+		generateSeedShootUsageMetrics(seed, shootUsage[seed.Name], c.descs[metricGardenSeedShootUsage], c.scrapeFailures, ch)
+		generateSeedConditionMetrics(seed, c.descs[metricGardenSeedCondition], c.scrapeFailures, ch)
+		generateSeedConditionLastTransitionMetrics(seed, c.descs[metricGardenSeedConditionLastTransition], c.scrapeFailures, ch)
+		generateSeedOperationStateMetrics(seed, c.descs[metricGardenSeedOperationState], c.scrapeFailures, ch)
 	}
 }
 
-func generateSeedOperationStateMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, ch chan<- prometheus.Metric) {
-	if seed.Status.LastOperation == nil {
+// seedShootUsageKey identifies the Shoots hosted by a Seed with the same purpose and hibernation state.
+type seedShootUsageKey struct {
+	purpose    string
+	hibernated bool
+}
+
+// generateSeedHeadroomMetrics exposes how many more Shoots can be scheduled to the Seed. The allocatable Shoots
+// default to the capacity of the Seed. No metric is exposed if the Seed does not limit the number of Shoots.
+func generateSeedHeadroomMetrics(seed *gardenv1beta1.Seed, hostedShoots float64, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric, visible, protected bool) {
+	allocatable, ok := seed.Status.Allocatable[gardenv1beta1.ResourceShoots]
+	if !ok {
+		if allocatable, ok = seed.Status.Capacity[gardenv1beta1.ResourceShoots]; !ok {
+			return
+		}
+	}
+
+	metric, err := prometheus.NewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		float64(allocatable.Value())-hostedShoots,
+		[]string{
+			seed.Name,
+			seed.Namespace,
+			seed.Spec.Provider.Type,
+			seed.Spec.Provider.Region,
+			strconv.FormatBool(visible),
+			strconv.FormatBool(protected),
+			gardenv1beta1.ResourceShoots.String(),
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
 		return
 	}
-	lastOperation := string(seed.Status.LastOperation.Type)
-	for _, operation := range seedOperations {
-		var operationState float64
-		if operation == lastOperation {
-			switch seed.Status.LastOperation.State {
-			case gardenv1beta1.LastOperationStateSucceeded:
-				operationState = 1
-			case gardenv1beta1.LastOperationStateProcessing:
-				operationState = 2
-			case gardenv1beta1.LastOperationStatePending:
-				operationState = 3
-			case gardenv1beta1.LastOperationStateAborted:
-				operationState = 4
-			case gardenv1beta1.LastOperationStateError:
-				operationState = 5
-			case gardenv1beta1.LastOperationStateFailed:
-				operationState = 6
-			}
+	ch <- metric
+}
+
+// generateSeedShootUsageMetrics exposes the number of Shoots hosted by the Seed per purpose and hibernation state.
+func generateSeedShootUsageMetrics(seed *gardenv1beta1.Seed, usage map[seedShootUsageKey]float64, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for key, count := range usage {
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			count,
+			[]string{
+				seed.Name,
+				seed.Spec.Provider.Type,
+				seed.Spec.Provider.Region,
+				key.purpose,
+				strconv.FormatBool(key.hibernated),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
+
+func generateSeedTaintMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, taint := range seed.Spec.Taints {
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			0,
+			[]string{
+				seed.Name,
+				taint.Key,
+				ptr.Deref(taint.Value, ""),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+			continue
 		}
+		ch <- metric
+	}
+}
+
+func generateSeedSettingMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, setting := range seedSettings(seed.Spec.Settings) {
 		metric, err := prometheus.NewConstMetric(
 			desc,
 			prometheus.GaugeValue,
-			operationState,
-			seed.Name,
-			operation,
+			0,
+			[]string{
+				seed.Name,
+				setting.name,
+				setting.value,
+			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+			scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
 			continue
 		}
 		ch <- metric
 	}
 }
 
-func generateSeedConditionMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, ch chan<- prometheus.Metric) {
+type seedSetting struct {
+	name, value string
+}
+
+// seedSettings flattens the settings of a Seed. The settings are named by their JSON path. Settings which are not
+// specified are omitted, unless Gardener documents a default for an unspecified field of a specified setting.
+func seedSettings(settings *gardenv1beta1.SeedSettings) []seedSetting {
+	if settings == nil {
+		return nil
+	}
+
+	var result []seedSetting
+
+	if s := settings.ExcessCapacityReservation; s != nil {
+		result = append(result, seedSetting{name: "excessCapacityReservation.enabled", value: strconv.FormatBool(ptr.Deref(s.Enabled, true))})
+		result = append(result, seedSetting{name: "excessCapacityReservation.configs", value: strconv.Itoa(len(s.Configs))})
+	}
+	if s := settings.Scheduling; s != nil {
+		result = append(result, seedSetting{name: "scheduling.visible", value: strconv.FormatBool(s.Visible)})
+	}
+	if s := settings.LoadBalancerServices; s != nil {
+		if s.ExternalTrafficPolicy != nil {
+			result = append(result, seedSetting{name: "loadBalancerServices.externalTrafficPolicy", value: string(*s.ExternalTrafficPolicy)})
+		}
+		result = append(result, seedSetting{name: "loadBalancerServices.proxyProtocol.allowed", value: strconv.FormatBool(s.ProxyProtocol != nil && s.ProxyProtocol.Allowed)})
+		result = append(result, seedSetting{name: "loadBalancerServices.zonalIngress.enabled", value: strconv.FormatBool(s.ZonalIngress == nil || ptr.Deref(s.ZonalIngress.Enabled, true))})
+		if s.Class != nil {
+			result = append(result, seedSetting{name: "loadBalancerServices.class", value: *s.Class})
+		}
+	}
+	if s := settings.VerticalPodAutoscaler; s != nil {
+		result = append(result, seedSetting{name: "verticalPodAutoscaler.enabled", value: strconv.FormatBool(s.Enabled)})
+	}
+	if s := settings.DependencyWatchdog; s != nil {
+		if s.Weeder != nil {
+			result = append(result, seedSetting{name: "dependencyWatchdog.weeder.enabled", value: strconv.FormatBool(s.Weeder.Enabled)})
+		}
+		if s.Prober != nil {
+			result = append(result, seedSetting{name: "dependencyWatchdog.prober.enabled", value: strconv.FormatBool(s.Prober.Enabled)})
+		}
+	}
+	if s := settings.TopologyAwareRouting; s != nil {
+		result = append(result, seedSetting{name: "topologyAwareRouting.enabled", value: strconv.FormatBool(s.Enabled)})
+	}
+	if s := settings.ZoneSelection; s != nil {
+		result = append(result, seedSetting{name: "zoneSelection.mode", value: string(s.Mode)})
+	}
+	return result
+}
+
+func generateSeedZoneMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, zone := range seed.Spec.Provider.Zones {
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			0,
+			[]string{
+				seed.Name,
+				seed.Spec.Provider.Region,
+				zone,
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// generateSeedBackupMetrics exposes the backup configuration of the Seed. No metric is exposed for Seeds without
+// backup. The backup region defaults to the region of the Seed.
+func generateSeedBackupMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	backup := seed.Spec.Backup
+	if backup == nil {
+		return
+	}
+
+	// The deprecated secretRef is no longer part of the API, so a Seed which only references the backup secret
+	// by the secretRef comes without a credentialsRef.
+	credentialsKind := "Secret"
+	if backup.CredentialsRef != nil {
+		credentialsKind = backup.CredentialsRef.Kind
+	}
+
+	metric, err := prometheus.NewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			seed.Name,
+			backup.Provider,
+			ptr.Deref(backup.Region, seed.Spec.Provider.Region),
+			credentialsKind,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		return
+	}
+	ch <- metric
+}
+
+func generateSeedIngressMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	ingress := seed.Spec.Ingress
+	if ingress == nil {
+		return
+	}
+
+	metric, err := prometheus.NewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			seed.Name,
+			ingress.Domain,
+			ingress.Controller.Kind,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		return
+	}
+	ch <- metric
+}
+
+func generateSeedDNSMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	provider := seed.Spec.DNS.Provider
+	if provider == nil {
+		return
+	}
+
+	metric, err := prometheus.NewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			seed.Name,
+			provider.Type,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		return
+	}
+	ch <- metric
+}
+
+// seedGardenerVersion returns the version of the gardenlet which reports the status of the Seed, nil if it is unknown.
+func seedGardenerVersion(seed *gardenv1beta1.Seed) *versionutil.Version {
+	if seed.Status.Gardener == nil {
+		return nil
+	}
+	version, err := versionutil.ParseSemantic(seed.Status.Gardener.Version)
+	if err != nil {
+		return nil
+	}
+	return version
+}
+
+// latestSeedGardenerVersion returns the newest gardenlet version of all Seeds, empty if no version is known.
+func latestSeedGardenerVersion(seeds []*gardenv1beta1.Seed) string {
+	var (
+		latest        *versionutil.Version
+		latestVersion string
+	)
+	for _, seed := range seeds {
+		if version := seedGardenerVersion(seed); version != nil && (latest == nil || version.GreaterThan(latest)) {
+			latest, latestVersion = version, seed.Status.Gardener.Version
+		}
+	}
+	return latestVersion
+}
+
+// generateSeedGardenerVersionMetrics exposes the gardenlet version of the Seed and how many minor versions it is
+// behind the newest gardenlet version of the landscape. The skew is only exposed for the same major version.
+func generateSeedGardenerVersionMetrics(seed *gardenv1beta1.Seed, latestVersion string, descs map[string]*prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	if seed.Status.Gardener == nil {
+		return
+	}
+
+	metric, err := prometheus.NewConstMetric(
+		descs[metricGardenSeedGardenerVersionInfo],
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			seed.Name,
+			seed.Status.Gardener.Version,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		return
+	}
+	ch <- metric
+
+	version := seedGardenerVersion(seed)
+	latest, err := versionutil.ParseSemantic(latestVersion)
+	if version == nil || err != nil || version.Major() != latest.Major() {
+		return
+	}
+	metric, err = prometheus.NewConstMetric(
+		descs[metricGardenSeedGardenerVersionSkew],
+		prometheus.GaugeValue,
+		float64(latest.Minor()-version.Minor()),
+		[]string{
+			seed.Name,
+			seed.Status.Gardener.Version,
+			latestVersion,
+		}...,
+	)
+	if err != nil {
+		scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		return
+	}
+	ch <- metric
+}
+
+func generateSeedOperationStateMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	generateOperationStateMetrics(seed.Status.LastOperation, desc, "seeds", scrapeFailures, ch, seed.Name)
+}
+
+func generateSeedConditionMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
 	for _, condition := range seed.Status.Conditions {
 		if condition.Type == "" {
 			continue
@@ -183,7 +508,7 @@ func generateSeedConditionMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Des
 			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+			scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
 			continue
 		}
 		ch <- metric
@@ -197,3 +522,26 @@ func getK8sVersion(seed *gardenv1beta1.Seed) string {
 	}
 	return version
 }
+
+func generateSeedConditionLastTransitionMetrics(seed *gardenv1beta1.Seed, desc *prometheus.Desc, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	for _, condition := range seed.Status.Conditions {
+		if condition.Type == "" || condition.LastTransitionTime.IsZero() {
+			continue
+		}
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			float64(condition.LastTransitionTime.Unix()),
+			[]string{
+				seed.Name,
+				string(condition.Type),
+				string(condition.Status),
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
@@ -6,10 +6,14 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func Test_generateSeedConditionMetrics(t *testing.T) {
@@ -39,7 +43,7 @@ func Test_generateSeedConditionMetrics(t *testing.T) {
 	)
 
 	ch := make(chan prometheus.Metric, 1)
-	generateSeedConditionMetrics(seed, desc, ch)
+	generateSeedConditionMetrics(seed, desc, testScrapeFailures, ch)
 
 	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 1,
 		"test-seed", "GardenletReady", "aws", "eu-west-1",
@@ -61,7 +65,7 @@ func Test_generateSeedConditionMetrics_skipsEmptyConditionType(t *testing.T) {
 	}
 
 	ch := make(chan prometheus.Metric, 1)
-	generateSeedConditionMetrics(seed, nil, ch)
+	generateSeedConditionMetrics(seed, nil, testScrapeFailures, ch)
 
 	if len(ch) != 0 {
 		t.Errorf("expected no metrics for empty condition type, got %d", len(ch))
@@ -77,7 +81,7 @@ func Test_generateSeedOperationStateMetrics_nilLastOperation(t *testing.T) {
 	}
 
 	ch := make(chan prometheus.Metric, 1)
-	generateSeedOperationStateMetrics(seed, nil, ch)
+	generateSeedOperationStateMetrics(seed, nil, testScrapeFailures, ch)
 
 	if len(ch) != 0 {
 		t.Errorf("expected no metrics for nil LastOperation, got %d", len(ch))
@@ -106,7 +110,7 @@ func Test_generateSeedOperationStateMetrics_activeOperation(t *testing.T) {
 	)
 
 	ch := make(chan prometheus.Metric, len(seedOperations))
-	generateSeedOperationStateMetrics(seed, desc, ch)
+	generateSeedOperationStateMetrics(seed, desc, testScrapeFailures, ch)
 
 	if len(ch) != len(seedOperations) {
 		t.Fatalf("expected %d metrics, got %d", len(seedOperations), len(ch))
@@ -165,7 +169,7 @@ func Test_generateSeedOperationStateMetrics_stateMapping(t *testing.T) {
 		}
 
 		ch := make(chan prometheus.Metric, len(seedOperations))
-		generateSeedOperationStateMetrics(seed, desc, ch)
+		generateSeedOperationStateMetrics(seed, desc, testScrapeFailures, ch)
 
 		// Drain until we find the Reconcile metric (second in seedOperations order).
 		var reconcileMetric prometheus.Metric
@@ -183,3 +187,248 @@ func Test_generateSeedOperationStateMetrics_stateMapping(t *testing.T) {
 		assert(t, expected, reconcileMetric)
 	}
 }
+
+func Test_generateSeedConditionLastTransitionMetrics(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Status: gardenv1beta1.SeedStatus{
+			Conditions: []gardenv1beta1.Condition{
+				{Type: "GardenletReady", Status: gardenv1beta1.ConditionFalse, LastTransitionTime: transition},
+				{Type: "BackupBucketsReady", Status: gardenv1beta1.ConditionTrue},
+			},
+		},
+	}
+
+	desc := getGardenMetricsDefinitions()[metricGardenSeedConditionLastTransition]
+
+	ch := make(chan prometheus.Metric, 2)
+	generateSeedConditionLastTransitionMetrics(seed, desc, testScrapeFailures, ch)
+
+	if len(ch) != 1 {
+		t.Fatalf("expected 1 metric for the condition with a transition time, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(transition.Unix()),
+		"test-seed", "GardenletReady", "False",
+	)
+	assert(t, expected, <-ch)
+}
+
+func Test_generateSeedHeadroomMetrics(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			Provider: gardenv1beta1.SeedProvider{Type: "aws", Region: "eu-west-1"},
+		},
+		Status: gardenv1beta1.SeedStatus{
+			Capacity: corev1.ResourceList{gardenv1beta1.ResourceShoots: resource.MustParse("250")},
+		},
+	}
+	desc := getGardenMetricsDefinitions()[metricGardenSeedHeadroom]
+
+	ch := make(chan prometheus.Metric, 1)
+	generateSeedHeadroomMetrics(seed, 40, desc, testScrapeFailures, ch, true, false)
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 210, "test-seed", "", "aws", "eu-west-1", "true", "false", "shoots")
+	assert(t, <-ch, expected)
+
+	// The allocatable Shoots take precedence over the capacity.
+	seed.Status.Allocatable = corev1.ResourceList{gardenv1beta1.ResourceShoots: resource.MustParse("200")}
+	generateSeedHeadroomMetrics(seed, 40, desc, testScrapeFailures, ch, true, false)
+	expected, _ = prometheus.NewConstMetric(desc, prometheus.GaugeValue, 160, "test-seed", "", "aws", "eu-west-1", "true", "false", "shoots")
+	assert(t, <-ch, expected)
+
+	seed.Status.Capacity, seed.Status.Allocatable = nil, nil
+	generateSeedHeadroomMetrics(seed, 40, desc, testScrapeFailures, ch, true, false)
+	if len(ch) != 0 {
+		t.Errorf("expected no metric for a Seed without a Shoot limit, got %d", len(ch))
+	}
+}
+
+func Test_generateSeedShootUsageMetrics(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			Provider: gardenv1beta1.SeedProvider{Type: "aws", Region: "eu-west-1"},
+		},
+	}
+	desc := getGardenMetricsDefinitions()[metricGardenSeedShootUsage]
+
+	ch := make(chan prometheus.Metric, 1)
+	generateSeedShootUsageMetrics(seed, map[seedShootUsageKey]float64{{purpose: "production", hibernated: true}: 3}, desc, testScrapeFailures, ch)
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 3, "test-seed", "aws", "eu-west-1", "production", "true")
+	assert(t, <-ch, expected)
+}
+
+func Test_generateSeedTaintMetrics(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			Taints: []gardenv1beta1.SeedTaint{
+				{Key: gardenv1beta1.SeedTaintProtected},
+				{Key: "example.com/maintenance", Value: ptr.To("planned")},
+			},
+		},
+	}
+	desc := getGardenMetricsDefinitions()[metricGardenSeedTaint]
+
+	ch := make(chan prometheus.Metric, 2)
+	generateSeedTaintMetrics(seed, desc, testScrapeFailures, ch)
+
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "test-seed", gardenv1beta1.SeedTaintProtected, "")
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "test-seed", "example.com/maintenance", "planned")
+	assert(t, <-ch, expected)
+}
+
+func Test_seedSettings(t *testing.T) {
+	assert(t, seedSettings(nil), []seedSetting(nil))
+
+	settings := &gardenv1beta1.SeedSettings{
+		ExcessCapacityReservation: &gardenv1beta1.SeedSettingExcessCapacityReservation{},
+		Scheduling:                &gardenv1beta1.SeedSettingScheduling{Visible: true},
+		LoadBalancerServices: &gardenv1beta1.SeedSettingLoadBalancerServices{
+			ExternalTrafficPolicy: ptr.To(corev1.ServiceExternalTrafficPolicyLocal),
+			ZonalIngress:          &gardenv1beta1.SeedSettingLoadBalancerServicesZonalIngress{Enabled: ptr.To(false)},
+		},
+		VerticalPodAutoscaler: &gardenv1beta1.SeedSettingVerticalPodAutoscaler{Enabled: true},
+		DependencyWatchdog: &gardenv1beta1.SeedSettingDependencyWatchdog{
+			Weeder: &gardenv1beta1.SeedSettingDependencyWatchdogWeeder{Enabled: true},
+			Prober: &gardenv1beta1.SeedSettingDependencyWatchdogProber{Enabled: false},
+		},
+		TopologyAwareRouting: &gardenv1beta1.SeedSettingTopologyAwareRouting{Enabled: true},
+		ZoneSelection:        &gardenv1beta1.SeedSettingZoneSelection{Mode: gardenv1beta1.ZoneSelectionModePrefer},
+	}
+	assert(t, seedSettings(settings), []seedSetting{
+		{"excessCapacityReservation.enabled", "true"},
+		{"excessCapacityReservation.configs", "0"},
+		{"scheduling.visible", "true"},
+		{"loadBalancerServices.externalTrafficPolicy", "Local"},
+		{"loadBalancerServices.proxyProtocol.allowed", "false"},
+		{"loadBalancerServices.zonalIngress.enabled", "false"},
+		{"verticalPodAutoscaler.enabled", "true"},
+		{"dependencyWatchdog.weeder.enabled", "true"},
+		{"dependencyWatchdog.prober.enabled", "false"},
+		{"topologyAwareRouting.enabled", "true"},
+		{"zoneSelection.mode", "Prefer"},
+	})
+}
+
+func Test_generateSeedZoneMetrics(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			Provider: gardenv1beta1.SeedProvider{Type: "aws", Region: "eu-west-1", Zones: []string{"eu-west-1a", "eu-west-1b"}},
+		},
+	}
+	desc := getGardenMetricsDefinitions()[metricGardenSeedZone]
+
+	ch := make(chan prometheus.Metric, 2)
+	generateSeedZoneMetrics(seed, desc, testScrapeFailures, ch)
+
+	for _, zone := range seed.Spec.Provider.Zones {
+		expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "test-seed", "eu-west-1", zone)
+		assert(t, <-ch, expected)
+	}
+}
+
+func Test_generateSeedBackupMetrics(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			Provider: gardenv1beta1.SeedProvider{Type: "aws", Region: "eu-west-1"},
+		},
+	}
+	desc := getGardenMetricsDefinitions()[metricGardenSeedBackupInfo]
+
+	ch := make(chan prometheus.Metric, 1)
+	generateSeedBackupMetrics(seed, desc, testScrapeFailures, ch)
+	if len(ch) != 0 {
+		t.Fatalf("expected no metric for a Seed without backup, got %d", len(ch))
+	}
+
+	seed.Spec.Backup = &gardenv1beta1.Backup{
+		Provider:       "aws",
+		CredentialsRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "garden", Name: "backup"},
+	}
+	generateSeedBackupMetrics(seed, desc, testScrapeFailures, ch)
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "test-seed", "aws", "eu-west-1", "Secret")
+	assert(t, <-ch, expected)
+
+	seed.Spec.Backup.CredentialsRef = &corev1.ObjectReference{APIVersion: "security.gardener.cloud/v1alpha1", Kind: "WorkloadIdentity", Namespace: "garden", Name: "backup"}
+	generateSeedBackupMetrics(seed, desc, testScrapeFailures, ch)
+	expected, _ = prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "test-seed", "aws", "eu-west-1", "WorkloadIdentity")
+	assert(t, <-ch, expected)
+}
+
+func Test_generateSeedBackupMetrics_deprecatedSecretRef(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			Provider: gardenv1beta1.SeedProvider{Type: "aws", Region: "eu-west-1"},
+			Backup:   &gardenv1beta1.Backup{Provider: "aws", Region: ptr.To("eu-central-1")},
+		},
+	}
+	desc := getGardenMetricsDefinitions()[metricGardenSeedBackupInfo]
+
+	ch := make(chan prometheus.Metric, 1)
+	generateSeedBackupMetrics(seed, desc, testScrapeFailures, ch)
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 0, "test-seed", "aws", "eu-central-1", "Secret")
+	assert(t, <-ch, expected)
+}
+
+func Test_generateSeedIngressAndDNSMetrics(t *testing.T) {
+	seed := &gardenv1beta1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-seed"},
+		Spec: gardenv1beta1.SeedSpec{
+			DNS: gardenv1beta1.SeedDNS{Provider: &gardenv1beta1.SeedDNSProvider{Type: "aws-route53"}},
+			Ingress: &gardenv1beta1.Ingress{
+				Domain:     "ingress.test-seed.example.com",
+				Controller: gardenv1beta1.IngressController{Kind: "nginx"},
+			},
+		},
+	}
+	descs := getGardenMetricsDefinitions()
+
+	ch := make(chan prometheus.Metric, 2)
+	generateSeedIngressMetrics(seed, descs[metricGardenSeedIngressInfo], testScrapeFailures, ch)
+	generateSeedDNSMetrics(seed, descs[metricGardenSeedDNSInfo], testScrapeFailures, ch)
+
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenSeedIngressInfo], prometheus.GaugeValue, 0, "test-seed", "ingress.test-seed.example.com", "nginx")
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenSeedDNSInfo], prometheus.GaugeValue, 0, "test-seed", "aws-route53")
+	assert(t, <-ch, expected)
+}
+
+func Test_generateSeedGardenerVersionMetrics(t *testing.T) {
+	newSeed := func(name, version string) *gardenv1beta1.Seed {
+		return &gardenv1beta1.Seed{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     gardenv1beta1.SeedStatus{Gardener: &gardenv1beta1.Gardener{Version: version}},
+		}
+	}
+	seeds := []*gardenv1beta1.Seed{
+		newSeed("seed-a", "v1.110.2"),
+		newSeed("seed-b", "v1.112.0-dev"),
+		newSeed("seed-c", "v1.111.1"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "seed-d"}},
+	}
+
+	latest := latestSeedGardenerVersion(seeds)
+	assert(t, latest, "v1.112.0-dev")
+	assert(t, latestSeedGardenerVersion(nil), "")
+
+	var (
+		descs = getGardenMetricsDefinitions()
+		ch    = make(chan prometheus.Metric, 2)
+	)
+	generateSeedGardenerVersionMetrics(seeds[0], latest, descs, testScrapeFailures, ch)
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenSeedGardenerVersionInfo], prometheus.GaugeValue, 0, "seed-a", "v1.110.2")
+	assert(t, <-ch, expected)
+	expected, _ = prometheus.NewConstMetric(descs[metricGardenSeedGardenerVersionSkew], prometheus.GaugeValue, 2, "seed-a", "v1.110.2", "v1.112.0-dev")
+	assert(t, <-ch, expected)
+
+	generateSeedGardenerVersionMetrics(seeds[3], latest, descs, testScrapeFailures, ch)
+	if len(ch) != 0 {
+		t.Errorf("expected no metrics for a Seed without gardenlet version, got %d", len(ch))
+	}
+}
@@ -5,14 +5,14 @@
 package metrics
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	constantsv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
-	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -28,345 +28,362 @@ var (
 		string(gardenv1beta1.LastOperationTypeRestore),
 	}
 
-	userErrorCodes = map[gardenv1beta1.ErrorCode]bool{
-		gardenv1beta1.ErrorInfraUnauthenticated:          true,
-		gardenv1beta1.ErrorInfraUnauthorized:             true,
-		gardenv1beta1.ErrorInfraQuotaExceeded:            true,
-		gardenv1beta1.ErrorInfraRateLimitsExceeded:       false,
-		gardenv1beta1.ErrorInfraDependencies:             true,
-		gardenv1beta1.ErrorRetryableInfraDependencies:    false,
-		gardenv1beta1.ErrorInfraResourcesDepleted:        true,
-		gardenv1beta1.ErrorCleanupClusterResources:       true,
-		gardenv1beta1.ErrorConfigurationProblem:          true,
-		gardenv1beta1.ErrorRetryableConfigurationProblem: true,
-		gardenv1beta1.ErrorProblematicWebhook:            true,
+	defaultErrorCodeClasses = map[gardenv1beta1.ErrorCode]string{
+		gardenv1beta1.ErrorInfraUnauthenticated:          names.ErrorClassUser,
+		gardenv1beta1.ErrorInfraUnauthorized:             names.ErrorClassUser,
+		gardenv1beta1.ErrorInfraQuotaExceeded:            names.ErrorClassUser,
+		gardenv1beta1.ErrorInfraRateLimitsExceeded:       names.ErrorClassInfrastructure,
+		gardenv1beta1.ErrorInfraDependencies:             names.ErrorClassUser,
+		gardenv1beta1.ErrorRetryableInfraDependencies:    names.ErrorClassInfrastructure,
+		gardenv1beta1.ErrorInfraResourcesDepleted:        names.ErrorClassUser,
+		gardenv1beta1.ErrorCleanupClusterResources:       names.ErrorClassUser,
+		gardenv1beta1.ErrorConfigurationProblem:          names.ErrorClassUser,
+		gardenv1beta1.ErrorRetryableConfigurationProblem: names.ErrorClassUser,
+		gardenv1beta1.ErrorProblematicWebhook:            names.ErrorClassUser,
 	}
 )
 
-// collectShootMetrics collect Shoot metrics.
-func (c gardenMetricsCollector) collectShootMetrics(ch chan<- prometheus.Metric) {
-	var (
-		shootOperationsCounters = make(map[string]float64)
-	)
-
-	// Fetch all Shoots.
-	shoots, err := c.shootInformer.Lister().Shoots(metav1.NamespaceAll).List(labels.Everything())
-	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-		return
+// shootObjects returns the object collector which exposes the metrics of every Shoot.
+func (c *gardenMetricsCollector) shootObjects() *objectCollector {
+	return &objectCollector{
+		list:          func() []interface{} { return c.shootInformer.Informer().GetStore().List() },
+		prepare:       c.prepareShootMetrics,
+		specResources: []string{resourceProjects, resourceManagedSeeds, resourceSeeds},
 	}
+}
+
+// shootDependencies holds the objects the metrics of all Shoots depend on.
+type shootDependencies struct {
+	projects      []*gardenv1beta1.Project
+	projectMap    map[string]*gardenv1beta1.Project
+	managedSeeds  []*seedmanagementv1alpha1.ManagedSeed
+	seeds         map[string]*gardenv1beta1.Seed
+	cloudProfiles *cloudProfileResolver
+}
 
+// prepareShootMetrics looks up the objects the metrics of all Shoots depend on and returns the function which
+// collects the metrics of a single Shoot.
+func (c *gardenMetricsCollector) prepareShootMetrics() (collectObjectFunc, error) {
 	projects, err := c.projectInformer.Lister().List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "projects-count"}).Inc()
-		return
+		c.scrapeFailures.With(prometheus.Labels{"kind": "projects-count"}).Inc()
+		return nil, err
 	}
 
 	managedSeeds, err := c.managedSeedInformer.Lister().ManagedSeeds(metav1.NamespaceAll).List(labels.Everything())
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "managedSeeds"}).Inc()
-		return
-	}
-
-	credentialsBindings, err := c.credentialsBindingInformer.Lister().CredentialsBindings(metav1.NamespaceAll).List(labels.Everything())
-	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "credentialsBindings"}).Inc()
-		return
-	}
-
-	secretBindings, err := c.secretBindingInformer.Lister().SecretBindings(metav1.NamespaceAll).List(labels.Everything())
-	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "secretBindings"}).Inc()
-		return
-	}
-
-	seeds := c.getSeeds()
-
-	collectShootCustomizationMetrics(shoots, ch)
-
-	credentialsBindingMap := make(map[string]*securityv1alpha1.CredentialsBinding)
-	for _, credentialsBinding := range credentialsBindings {
-		credentialsBindingMap[fmt.Sprintf("%s/%s", credentialsBinding.Namespace, credentialsBinding.Name)] = credentialsBinding
+		c.scrapeFailures.With(prometheus.Labels{"kind": "managedSeeds"}).Inc()
+		return nil, err
 	}
 
-	secretBindingMap := make(map[string]*gardenv1beta1.SecretBinding) // nolint:staticcheck // SA1019: gardenv1beta1.SecretBinding is deprecated
-	for _, secretBinding := range secretBindings {
-		secretBindingMap[fmt.Sprintf("%s/%s", secretBinding.Namespace, secretBinding.Name)] = secretBinding
+	dependencies := &shootDependencies{
+		projects:      projects,
+		projectMap:    make(map[string]*gardenv1beta1.Project),
+		managedSeeds:  managedSeeds,
+		seeds:         c.getSeeds(),
+		cloudProfiles: newCloudProfileResolver(c.cloudProfileInformer.Lister(), c.namespacedCloudProfileInformer.Lister()),
 	}
-
-	projectMap := make(map[string]*gardenv1beta1.Project)
 	for _, project := range projects {
-		projectMap[*project.Spec.Namespace] = project
+		if project.Spec.Namespace != nil {
+			dependencies.projectMap[*project.Spec.Namespace] = project
+		}
 	}
 
-	for _, shoot := range shoots {
-		var costObject, costObjectType, costObjectOwner, secretBindingName, credentialsBindingName string
-
-		if shoot.Spec.SecretBindingName != nil { // nolint:staticcheck // SA1019: shoot.Spec.SecretBindingName is deprecated
-			secretBindingName = fmt.Sprintf("%s/%s", shoot.Namespace, *shoot.Spec.SecretBindingName) // nolint:staticcheck // SA1019: shoot.Spec.SecretBindingName is deprecated
-		} else if shoot.Spec.CredentialsBindingName != nil {
-			credentialsBindingName = fmt.Sprintf("%s/%s", shoot.Namespace, *shoot.Spec.CredentialsBindingName)
+	return func(obj interface{}, ch chan<- prometheus.Metric, aggregated aggregation) {
+		if shoot, ok := obj.(*gardenv1beta1.Shoot); ok {
+			c.collectShootMetrics(shoot, dependencies, ch, aggregated)
 		}
+	}, nil
+}
 
-		var projectNamespace string
-		if secretBinding, ok := secretBindingMap[secretBindingName]; ok {
-			projectNamespace = secretBinding.SecretRef.Namespace
-		} else if credentialsBinding, ok := credentialsBindingMap[credentialsBindingName]; ok {
-			projectNamespace = credentialsBinding.CredentialsRef.Namespace
-		} else {
-			projectNamespace = shoot.Namespace
+// shootProjectNamespace returns the namespace of the credentials the Shoot's binding refers to. It falls back to
+// the namespace of the Shoot.
+func (c *gardenMetricsCollector) shootProjectNamespace(shoot *gardenv1beta1.Shoot) string {
+	if shoot.Spec.SecretBindingName != nil { // nolint:staticcheck // SA1019: shoot.Spec.SecretBindingName is deprecated
+		if secretBinding, err := c.secretBindingInformer.Lister().SecretBindings(shoot.Namespace).Get(*shoot.Spec.SecretBindingName); err == nil { // nolint:staticcheck // SA1019: shoot.Spec.SecretBindingName is deprecated
+			return secretBinding.SecretRef.Namespace
 		}
-
-		if project, ok := projectMap[projectNamespace]; ok {
-			costObject = project.GetObjectMeta().GetAnnotations()["billing.gardener.cloud/costObject"]
-			costObjectType = project.GetObjectMeta().GetAnnotations()["billing.gardener.cloud/costObjectType"]
-			costObjectOwner = project.Spec.Owner.Name
+	} else if shoot.Spec.CredentialsBindingName != nil {
+		if credentialsBinding, err := c.credentialsBindingInformer.Lister().CredentialsBindings(shoot.Namespace).Get(*shoot.Spec.CredentialsBindingName); err == nil {
+			return credentialsBinding.CredentialsRef.Namespace
 		}
+	}
+	return shoot.Namespace
+}
 
-		var failureTolerance string
-		if shoot.Spec.ControlPlane != nil && shoot.Spec.ControlPlane.HighAvailability != nil {
-			failureTolerance = string(shoot.Spec.ControlPlane.HighAvailability.FailureTolerance.Type)
-		}
+// collectShootMetrics collects the metrics of a single Shoot and adds its contributions to the counts of
+// operations, errors and deprecated versions of all Shoots.
+func (c *gardenMetricsCollector) collectShootMetrics(shoot *gardenv1beta1.Shoot, dependencies *shootDependencies, ch chan<- prometheus.Metric, aggregated aggregation) {
+	var (
+		shootErrorCounts        = make(map[shootErrorKey]float64)
+		deprecatedVersionCounts = make(map[shootDeprecatedVersionKey]float64)
+	)
 
-		var (
-			isSeed       bool
-			purpose, uid string
+	var costObject, costObjectType, costObjectOwner string
+	if project, ok := dependencies.projectMap[c.shootProjectNamespace(shoot)]; ok {
+		costObject = project.GetObjectMeta().GetAnnotations()[c.labelOptions.CostObjectAnnotation]
+		costObjectType = project.GetObjectMeta().GetAnnotations()[c.labelOptions.CostObjectTypeAnnotation]
+		costObjectOwner = project.Spec.Owner.Name
+	}
 
-			iaas = shoot.Spec.Provider.Type
-			seed = ptr.Deref(shoot.Spec.SeedName, "")
-		)
-		isSeed = usedAsSeed(shoot, managedSeeds)
+	var failureTolerance string
+	if shoot.Spec.ControlPlane != nil && shoot.Spec.ControlPlane.HighAvailability != nil {
+		failureTolerance = string(shoot.Spec.ControlPlane.HighAvailability.FailureTolerance.Type)
+	}
 
-		if shoot.Spec.Purpose != nil {
-			purpose = string(*shoot.Spec.Purpose)
-		}
+	var (
+		isSeed bool
+		uid    string
 
-		if shoot.Labels != nil {
-			for k, v := range shoot.Labels {
-				if k == "business-critical" && v == "true" {
-					purpose = "business-critical"
-				}
-			}
-		}
+		iaas    = shoot.Spec.Provider.Type
+		seed    = ptr.Deref(shoot.Spec.SeedName, "")
+		purpose = shootPurpose(shoot)
+	)
+	isSeed = usedAsSeed(shoot, dependencies.managedSeeds)
 
-		projectName, err := findProject(projects, shoot.Namespace)
-		if err != nil {
-			c.logger.Error(err.Error())
-			continue
-		}
+	projectName, err := findProject(dependencies.projects, shoot.Namespace)
+	if err != nil {
+		c.logger.Error(err.Error())
+		return
+	}
 
-		var seedProviderType, seedRegion string
-		if seed != "" {
-			seedProviderType = seeds[seed].Spec.Provider.Type
-			seedRegion = seeds[seed].Spec.Provider.Region
-		}
+	var seedProviderType, seedRegion string
+	if seed != "" {
+		seedProviderType = dependencies.seeds[seed].Spec.Provider.Type
+		seedRegion = dependencies.seeds[seed].Spec.Provider.Region
+	}
 
-		isWorkerless := shoot.Spec.Provider.Workers == nil
+	isWorkerless := shoot.Spec.Provider.Workers == nil
 
-		// Expose a metric, which transport basic information to the Shoot cluster via the metric labels.
-		metric, err := prometheus.NewConstMetric(
-			c.descs[metricGardenShootInfo],
-			prometheus.GaugeValue,
-			0,
-			[]string{
-				shoot.Name,
-				*projectName,
-				iaas,
-				shoot.Spec.Kubernetes.Version,
-				shoot.Spec.Region,
-				seed,
-				strconv.FormatBool(isSeed),
-				seedProviderType,
-				seedRegion,
-				string(shoot.UID),
-				costObject,
-				costObjectType,
-				costObjectOwner,
-				failureTolerance,
-				shoot.Status.TechnicalID,
-				shoot.Status.Gardener.Version,
-				strconv.FormatBool(isWorkerless),
-				strconv.FormatBool(shoot.Status.IsHibernated),
-				shoot.Labels[constantsv1beta1.ShootStatus],
-			}...,
-		)
+	// Expose a metric, which transport basic information to the Shoot cluster via the metric labels.
+	metric, err := prometheus.NewConstMetric(
+		c.descs[metricGardenShootInfo],
+		prometheus.GaugeValue,
+		0,
+		[]string{
+			shoot.Name,
+			*projectName,
+			iaas,
+			shoot.Spec.Kubernetes.Version,
+			shoot.Spec.Region,
+			seed,
+			strconv.FormatBool(isSeed),
+			seedProviderType,
+			seedRegion,
+			string(shoot.UID),
+			costObject,
+			costObjectType,
+			costObjectOwner,
+			failureTolerance,
+			shoot.Status.TechnicalID,
+			shoot.Status.Gardener.Version,
+			strconv.FormatBool(isWorkerless),
+			strconv.FormatBool(shoot.Status.IsHibernated),
+			shoot.Labels[constantsv1beta1.ShootStatus],
+		}...,
+	)
 
-		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-			continue
-		}
-		ch <- metric
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+		return
+	}
+	ch <- metric
 
-		hibernatedVal := 0
+	hibernatedVal := 0
 
-		if shoot.Status.IsHibernated {
-			hibernatedVal = 1
-		}
+	if shoot.Status.IsHibernated {
+		hibernatedVal = 1
+	}
 
-		uid = string(shoot.UID)
+	uid = string(shoot.UID)
 
-		labels := []string{
-			shoot.Name,
-			*projectName,
-			uid,
-			shoot.Status.TechnicalID,
-		}
+	labels := []string{
+		shoot.Name,
+		*projectName,
+		uid,
+		shoot.Status.TechnicalID,
+	}
 
-		metric, err = prometheus.NewConstMetric(
-			c.descs[metricGardenShootHibernated],
-			prometheus.GaugeValue,
-			float64(hibernatedVal),
-			labels...,
-		)
-		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-			continue
-		}
+	metric, err = prometheus.NewConstMetric(
+		c.descs[metricGardenShootHibernated],
+		prometheus.GaugeValue,
+		float64(hibernatedVal),
+		labels...,
+	)
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+		return
+	}
 
-		ch <- metric
+	ch <- metric
 
-		shootCreation := shoot.CreationTimestamp
-		metric, err = prometheus.NewConstMetric(
-			c.descs[metricGardenShootCreation],
-			prometheus.GaugeValue,
-			float64(shootCreation.Unix()),
-			labels...,
-		)
-		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-			continue
-		}
+	shootCreation := shoot.CreationTimestamp
+	metric, err = prometheus.NewConstMetric(
+		c.descs[metricGardenShootCreation],
+		prometheus.GaugeValue,
+		float64(shootCreation.Unix()),
+		labels...,
+	)
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+		return
+	}
 
-		ch <- metric
+	ch <- metric
 
-		// Collect metrics to the node count of the Shoot.
-		c.collectShootNodeMetrics(shoot, projectName, ch)
-
-		if shoot.Status.LastOperation != nil {
-			lastOperation := string(shoot.Status.LastOperation.Type)
-			lastOperationState := string(shoot.Status.LastOperation.State)
-
-			// Export a metric for any possible operation, which can be ongoing on the Shoot.
-			// For currently non ongoing operations the value of the metric not will be set to 0.
-			for _, operation := range shootOperations {
-				var operationState float64
-				var operationProgress float64
-				if operation == lastOperation {
-					switch shoot.Status.LastOperation.State {
-					case gardenv1beta1.LastOperationStateSucceeded:
-						operationState = 1
-					case gardenv1beta1.LastOperationStateProcessing:
-						operationState = 2
-					case gardenv1beta1.LastOperationStatePending:
-						operationState = 3
-					case gardenv1beta1.LastOperationStateAborted:
-						operationState = 4
-					case gardenv1beta1.LastOperationStateError:
-						operationState = 5
-					case gardenv1beta1.LastOperationStateFailed:
-						operationState = 6
-					}
-					operationProgress = float64(shoot.Status.LastOperation.Progress)
-				}
+	// Collect metrics to the node count of the Shoot.
+	c.collectShootNodeMetrics(shoot, projectName, ch)
 
-				metric, err := prometheus.NewConstMetric(
-					c.descs[metricGardenShootOperationState],
-					prometheus.GaugeValue,
-					operationState,
-					[]string{
-						shoot.Name,
-						*projectName,
-						operation,
-						shoot.Status.TechnicalID,
-					}...,
-				)
-				if err != nil {
-					ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-					continue
-				}
-				ch <- metric
-				metric, err = prometheus.NewConstMetric(
-					c.descs[metricGardenShootOperationProgressPercent],
-					prometheus.GaugeValue,
-					operationProgress,
-					[]string{
-						shoot.Name,
-						*projectName,
-						operation,
-						shoot.Status.TechnicalID,
-					}...,
-				)
-				if err != nil {
-					ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-					continue
+	// Collect metrics to the last errors of the Shoot.
+	generateShootLastErrorMetrics(shoot, *projectName, c.errorClassifier, c.descs[metricGardenShootLastError], shootErrorCounts, c.scrapeFailures, ch)
+	for key, count := range shootErrorCounts {
+		aggregated.add(c.descs[metricGardenShootErrorsTotal], count, key.code, key.classification, key.iaas, key.seed)
+	}
+
+	// Collect metrics to the expiration of the versions the Shoot runs.
+	cloudProfileSpec, err := dependencies.cloudProfiles.resolve(shoot)
+	if err != nil {
+		c.logger.Debugf("Could not resolve the cloud profile of shoot %s/%s: %s", shoot.Namespace, shoot.Name, err.Error())
+	}
+	generateShootVersionMetrics(shoot, *projectName, cloudProfileSpec, c.descs[metricGardenShootVersionExpiration], deprecatedVersionCounts, c.scrapeFailures, ch)
+	for key, count := range deprecatedVersionCounts {
+		aggregated.add(c.descs[metricGardenShootDeprecatedVersionsTotal], count, key.project, key.component)
+	}
+
+	if shoot.Status.LastOperation != nil {
+		lastOperation := string(shoot.Status.LastOperation.Type)
+		lastOperationState := string(shoot.Status.LastOperation.State)
+
+		// Export a metric for any possible operation, which can be ongoing on the Shoot.
+		// For currently non ongoing operations the value of the metric not will be set to 0.
+		for _, operation := range shootOperations {
+			var operationState float64
+			var operationProgress float64
+			if operation == lastOperation {
+				switch shoot.Status.LastOperation.State {
+				case gardenv1beta1.LastOperationStateSucceeded:
+					operationState = 1
+				case gardenv1beta1.LastOperationStateProcessing:
+					operationState = 2
+				case gardenv1beta1.LastOperationStatePending:
+					operationState = 3
+				case gardenv1beta1.LastOperationStateAborted:
+					operationState = 4
+				case gardenv1beta1.LastOperationStateError:
+					operationState = 5
+				case gardenv1beta1.LastOperationStateFailed:
+					operationState = 6
 				}
-				ch <- metric
+				operationProgress = float64(shoot.Status.LastOperation.Progress)
 			}
 
-			// Export a metric for each condition of the Shoot.
-			for _, condition := range shoot.Status.Conditions {
-				if condition.Type == "" {
-					continue
-				}
+			metric, err := prometheus.NewConstMetric(
+				c.descs[metricGardenShootOperationState],
+				prometheus.GaugeValue,
+				operationState,
+				[]string{
+					shoot.Name,
+					*projectName,
+					operation,
+					shoot.Status.TechnicalID,
+				}...,
+			)
+			if err != nil {
+				c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				continue
+			}
+			ch <- metric
+			metric, err = prometheus.NewConstMetric(
+				c.descs[metricGardenShootOperationProgressPercent],
+				prometheus.GaugeValue,
+				operationProgress,
+				[]string{
+					shoot.Name,
+					*projectName,
+					operation,
+					shoot.Status.TechnicalID,
+				}...,
+			)
+			if err != nil {
+				c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
 
-				metric, err := prometheus.NewConstMetric(
-					c.descs[metricGardenShootCondition],
-					prometheus.GaugeValue,
-					mapConditionStatus(condition.Status),
-					[]string{
-						shoot.Name,
-						*projectName,
-						string(condition.Type),
-						lastOperation,
-						purpose,
-						strconv.FormatBool(isSeed),
-						iaas,
-						seed,
-						seedProviderType,
-						seedRegion,
-						uid,
-						shoot.Status.TechnicalID,
-						strconv.FormatBool(hasUserErrors(shoot.Status.LastErrors)),
-						shootIsCompliant(shoot.Status.Constraints),
-					}...,
-				)
-				if err != nil {
-					ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
-					continue
-				}
-				ch <- metric
+		// Export a metric for each condition of the Shoot.
+		for _, condition := range shoot.Status.Conditions {
+			if condition.Type == "" {
+				continue
 			}
 
-			// Collect the current count of ongoing operations.
-			if !isSeed {
-				shootOperationsCounters[fmt.Sprintf(
-					"%s:%s:%s:%s:%s:%s",
+			metric, err := prometheus.NewConstMetric(
+				c.descs[metricGardenShootCondition],
+				prometheus.GaugeValue,
+				mapConditionStatus(condition.Status),
+				[]string{
+					shoot.Name,
+					*projectName,
+					string(condition.Type),
 					lastOperation,
-					lastOperationState,
+					purpose,
+					strconv.FormatBool(isSeed),
 					iaas,
 					seed,
-					shoot.Spec.Kubernetes.Version,
-					shoot.Spec.Region,
-				)]++
+					seedProviderType,
+					seedRegion,
+					uid,
+					shoot.Status.TechnicalID,
+					strconv.FormatBool(c.errorClassifier.hasUserErrors(shoot.Status.LastErrors)),
+					strings.Join(c.errorClassifier.classes(shoot.Status.LastErrors), ","),
+					shootIsCompliant(shoot.Status.Constraints),
+				}...,
+			)
+			if err != nil {
+				c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				continue
 			}
-		}
-	}
+			ch <- metric
 
-	c.exposeShootOperations(shootOperationsCounters, ch)
-}
-
-func hasUserErrors(lastErrors []gardenv1beta1.LastError) bool {
-	for _, lastError := range lastErrors {
-		for _, code := range lastError.Codes {
-			if userError := userErrorCodes[code]; userError {
-				return true
+			if condition.LastTransitionTime.IsZero() {
+				continue
 			}
+			metric, err = prometheus.NewConstMetric(
+				c.descs[metricGardenShootConditionLastTransition],
+				prometheus.GaugeValue,
+				float64(condition.LastTransitionTime.Unix()),
+				[]string{
+					shoot.Name,
+					*projectName,
+					string(condition.Type),
+					string(condition.Status),
+					shoot.Status.TechnicalID,
+				}...,
+			)
+			if err != nil {
+				c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+				continue
+			}
+			ch <- metric
+		}
+
+		// Collect the current count of ongoing operations.
+		if !isSeed {
+			aggregated.add(
+				c.descs[metricGardenOperationsTotal],
+				1,
+				lastOperation,
+				lastOperationState,
+				iaas,
+				seed,
+				shoot.Spec.Kubernetes.Version,
+				shoot.Spec.Region,
+			)
 		}
 	}
-	return false
 }
 
-func (c gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Shoot, projectName *string, ch chan<- prometheus.Metric) {
+func (c *gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Shoot, projectName *string, ch chan<- prometheus.Metric) {
 	var (
 		nodeCountMax int32
 		nodeCountMin int32
@@ -388,7 +405,7 @@ func (c gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Sho
 			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+			c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 			return
 		}
 		ch <- metric
@@ -407,7 +424,7 @@ func (c gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Sho
 			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+			c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 			return
 		}
 		ch <- metric
@@ -446,7 +463,7 @@ func (c gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Sho
 			}...,
 		)
 		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+			c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 			return
 		}
 		ch <- metric
@@ -464,7 +481,7 @@ func (c gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Sho
 		}...,
 	)
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 		return
 	}
 	ch <- metric
@@ -481,36 +498,17 @@ func (c gardenMetricsCollector) collectShootNodeMetrics(shoot *gardenv1beta1.Sho
 		}...,
 	)
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
 		return
 	}
 	ch <- metric
 }
 
-// exposeShootOperations is a util function which is used to transform a map
-// of Shoot operations information into proper metrics and to pass them to the collector.
-func (c gardenMetricsCollector) exposeShootOperations(shootOperations map[string]float64, ch chan<- prometheus.Metric) {
-	for operationInfos, count := range shootOperations {
-		labels := strings.Split(operationInfos, ":")
-		metric, err := prometheus.NewConstMetric(
-			c.descs[metricGardenOperationsTotal],
-			prometheus.GaugeValue,
-			count,
-			labels...,
-		)
-		if err != nil {
-			ScrapeFailures.With(prometheus.Labels{"kind": "shoots-operations-total"}).Inc()
-			continue
-		}
-		ch <- metric
-	}
-}
-
-func (c gardenMetricsCollector) getSeeds() map[string]*gardenv1beta1.Seed {
+func (c *gardenMetricsCollector) getSeeds() map[string]*gardenv1beta1.Seed {
 	s, err := c.seedInformer.Lister().List(labels.Everything())
 	seeds := make(map[string]*gardenv1beta1.Seed)
 	if err != nil {
-		ScrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
+		c.scrapeFailures.With(prometheus.Labels{"kind": "seeds"}).Inc()
 	}
 
 	for _, seed := range s {
@@ -522,6 +520,15 @@ func (c gardenMetricsCollector) getSeeds() map[string]*gardenv1beta1.Seed {
 	return seeds
 }
 
+// shootPurpose returns the purpose of the Shoot. Shoots labeled as business-critical are reported with the purpose
+// "business-critical".
+func shootPurpose(shoot *gardenv1beta1.Shoot) string {
+	if shoot.Labels["business-critical"] == "true" {
+		return "business-critical"
+	}
+	return string(ptr.Deref(shoot.Spec.Purpose, ""))
+}
+
 func shootIsCompliant(constraints []gardenv1beta1.Condition) string {
 	for _, constraint := range constraints {
 		if constraint.Type == gardenv1beta1.ShootMaintenancePreconditionsSatisfied {
@@ -6,11 +6,14 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/gardener/gardener-metrics-exporter/pkg/template"
 	"github.com/gardener/gardener-metrics-exporter/pkg/utils"
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -561,21 +564,46 @@ var shootCustomizationMetrics = []*template.MetricTemplate{
 	},
 }
 
-func registerShootCustomizationMetrics(ch chan<- *prometheus.Desc) {
-	for _, c := range shootCustomizationMetrics {
+// newShootCustomizationMetrics returns copies of the Shoot customization metric templates with the given constant labels.
+func newShootCustomizationMetrics(constLabels prometheus.Labels) []*template.MetricTemplate {
+	templates := make([]*template.MetricTemplate, 0, len(shootCustomizationMetrics))
+	for _, t := range shootCustomizationMetrics {
+		templates = append(templates, t.WithConstLabels(constLabels))
+	}
+	return templates
+}
+
+func registerShootCustomizationMetrics(templates []*template.MetricTemplate, ch chan<- *prometheus.Desc) {
+	for _, c := range templates {
 		c.Register(ch)
 	}
 }
 
-func collectShootCustomizationMetrics(shoots []*gardenv1beta1.Shoot, ch chan<- prometheus.Metric) {
-	var (
-		run = func(c *template.MetricTemplate) {
-			c.Collect(ch, shoots)
-		}
-	)
+// collectShootCustomizationMetrics collects Shoot customization metrics.
+func (c *gardenMetricsCollector) collectShootCustomizationMetrics(ch chan<- prometheus.Metric) {
+	shoots, err := c.shootInformer.Lister().Shoots(metav1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		c.scrapeFailures.With(prometheus.Labels{"kind": "shoots-customization"}).Inc()
+		return
+	}
+
+	generateShootCustomizationMetrics(c.customizationMetrics, shoots, c.metricFilter, ch)
+}
 
-	for _, c := range shootCustomizationMetrics {
-		run(c)
+// generateShootCustomizationMetrics exposes the series of a template if its name is allowed by the filter. They are
+// also exposed as series of the merged customization metric if it is allowed and the name of the template is not
+// denied, so that denying a single customization metric also removes it from the merged metric.
+func generateShootCustomizationMetrics(templates []*template.MetricTemplate, shoots []*gardenv1beta1.Shoot, filter MetricFilter, ch chan<- prometheus.Metric) {
+	for _, c := range templates {
+		options := template.CollectOptions{
+			Series: filter.allowed(c.Name),
+			Merged: filter.allowed(metricShootsCustomPrefix) && !slices.Contains(filter.Deny, c.Name),
+		}
+		// Skip the computation of metrics which are not exposed anyway.
+		if !options.Series && !options.Merged {
+			continue
+		}
+		c.CollectWithOptions(ch, options, shoots)
 	}
 }
 
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"slices"
+	"testing"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/utils/ptr"
+)
+
+// customizationSeries returns the label names of the series the templates generate for the shoots and the
+// customizations of the series of the merged customization metric.
+func customizationSeries(t *testing.T, shoots []*gardenv1beta1.Shoot, filter MetricFilter) (series, merged []string) {
+	// The templates are collected without being registered before, like in the first refresh of the snapshot.
+	templates := newShootCustomizationMetrics(nil)
+	ch := make(chan prometheus.Metric, 100)
+	generateShootCustomizationMetrics(templates, shoots, filter, ch)
+	close(ch)
+
+	for metric := range ch {
+		dtoMetric := &dto.Metric{}
+		if err := metric.Write(dtoMetric); err != nil {
+			t.Fatalf("could not write metric: %v", err)
+		}
+		var customization, labelNames string
+		for _, label := range dtoMetric.Label {
+			if label.GetName() == "customization" {
+				customization = label.GetValue()
+			}
+			labelNames += label.GetName() + ","
+		}
+		if customization != "" {
+			merged = append(merged, customization)
+		} else {
+			series = append(series, labelNames)
+		}
+	}
+	return series, merged
+}
+
+func Test_generateShootCustomizationMetrics_filtersMergedSeries(t *testing.T) {
+	shoots := []*gardenv1beta1.Shoot{{
+		Spec: gardenv1beta1.ShootSpec{
+			Extensions: []gardenv1beta1.Extension{{Type: "shoot-dns-service"}},
+			Maintenance: &gardenv1beta1.Maintenance{
+				AutoUpdate: &gardenv1beta1.MaintenanceAutoUpdate{MachineImageVersion: ptr.To(true)},
+			},
+		},
+	}}
+	extensions := metricShootsCustomPrefix + "_extensions_total"
+
+	_, merged := customizationSeries(t, shoots, MetricFilter{})
+	if !slices.Contains(merged, "extensions_total") {
+		t.Fatalf("expected a merged series for the extensions without filter, got %v", merged)
+	}
+
+	series, merged := customizationSeries(t, shoots, MetricFilter{Deny: []string{extensions}})
+	if slices.Contains(merged, "extensions_total") {
+		t.Errorf("expected no merged series for the denied extensions metric, got %v", merged)
+	}
+	if len(merged) == 0 {
+		t.Errorf("expected merged series for the other customizations")
+	}
+	if slices.Contains(series, "extension,") {
+		t.Errorf("expected no series of the denied extensions metric, got %v", series)
+	}
+
+	series, merged = customizationSeries(t, shoots, MetricFilter{Allow: []string{extensions}})
+	if len(merged) != 0 {
+		t.Errorf("expected no merged series if the merged metric is not allowed, got %v", merged)
+	}
+	if !slices.Equal(series, []string{"extension,"}) {
+		t.Errorf("expected only the series of the allowed extensions metric, got %v", series)
+	}
+}
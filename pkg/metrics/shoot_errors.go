@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+)
+
+// errorClassifier maps error codes to their class.
+type errorClassifier map[gardenv1beta1.ErrorCode]string
+
+// newErrorClassifier returns the default classification of the error codes, overridden by the given classes.
+func newErrorClassifier(classes map[string]string) errorClassifier {
+	classifier := make(errorClassifier, len(defaultErrorCodeClasses)+len(classes))
+	for code, class := range defaultErrorCodeClasses {
+		classifier[code] = class
+	}
+	for code, class := range classes {
+		classifier[gardenv1beta1.ErrorCode(code)] = class
+	}
+	return classifier
+}
+
+// classify returns the class of the error code. Errors without a classified code are unknown.
+func (e errorClassifier) classify(code gardenv1beta1.ErrorCode) string {
+	if class, ok := e[code]; ok {
+		return class
+	}
+	return names.ErrorClassUnknown
+}
+
+// classes returns the sorted classes of all error codes of the last errors.
+func (e errorClassifier) classes(lastErrors []gardenv1beta1.LastError) []string {
+	classes := sets.New[string]()
+	for _, lastError := range lastErrors {
+		if len(lastError.Codes) == 0 {
+			classes.Insert(names.ErrorClassUnknown)
+		}
+		for _, code := range lastError.Codes {
+			classes.Insert(e.classify(code))
+		}
+	}
+	return sets.List(classes)
+}
+
+// hasUserErrors reports whether one of the last errors has an error code of the user class.
+func (e errorClassifier) hasUserErrors(lastErrors []gardenv1beta1.LastError) bool {
+	for _, lastError := range lastErrors {
+		for _, code := range lastError.Codes {
+			if e.classify(code) == names.ErrorClassUser {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shootErrorKey groups the Shoots with errors for the aggregated error metric.
+type shootErrorKey struct {
+	code           string
+	classification string
+	iaas           string
+	seed           string
+}
+
+// lastErrorKey identifies a series of the last error metric of a Shoot.
+type lastErrorKey struct {
+	code   gardenv1beta1.ErrorCode
+	taskID string
+}
+
+// generateShootLastErrorMetrics exposes a metric for each code of each last error of the Shoot. The value is the
+// time the error was reported last. Errors with the same code and task share a series, which carries the newest
+// time. Each code is counted once per Shoot in errorCounts.
+func generateShootLastErrorMetrics(shoot *gardenv1beta1.Shoot, projectName string, classifier errorClassifier, desc *prometheus.Desc, errorCounts map[shootErrorKey]float64, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	var (
+		codes           = sets.New[gardenv1beta1.ErrorCode]()
+		keys            []lastErrorKey
+		lastUpdateTimes = make(map[lastErrorKey]float64)
+	)
+	for _, lastError := range shoot.Status.LastErrors {
+		var lastUpdateTime float64
+		if lastError.LastUpdateTime != nil {
+			lastUpdateTime = float64(lastError.LastUpdateTime.Unix())
+		}
+
+		errorCodes := lastError.Codes
+		if len(errorCodes) == 0 {
+			errorCodes = []gardenv1beta1.ErrorCode{""}
+		}
+		for _, code := range errorCodes {
+			codes.Insert(code)
+			key := lastErrorKey{code: code, taskID: ptr.Deref(lastError.TaskID, "")}
+			if previous, ok := lastUpdateTimes[key]; !ok {
+				keys = append(keys, key)
+			} else if previous > lastUpdateTime {
+				continue
+			}
+			lastUpdateTimes[key] = lastUpdateTime
+		}
+	}
+
+	for _, key := range keys {
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			lastUpdateTimes[key],
+			[]string{
+				shoot.Name,
+				projectName,
+				string(key.code),
+				classifier.classify(key.code),
+				key.taskID,
+				shoot.Status.TechnicalID,
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+
+	for code := range codes {
+		errorCounts[shootErrorKey{
+			code:           string(code),
+			classification: classifier.classify(code),
+			iaas:           shoot.Spec.Provider.Type,
+			seed:           ptr.Deref(shoot.Spec.SeedName, ""),
+		}]++
+	}
+}
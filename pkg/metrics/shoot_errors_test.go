@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/metrics/names"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_generateShootLastErrorMetrics(t *testing.T) {
+	lastUpdateTime := metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	shoot := &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-shoot"},
+		Spec: gardenv1beta1.ShootSpec{
+			Provider: gardenv1beta1.Provider{Type: "aws"},
+			SeedName: ptr.To("test-seed"),
+		},
+		Status: gardenv1beta1.ShootStatus{
+			TechnicalID: "shoot--test--test-shoot",
+			LastErrors: []gardenv1beta1.LastError{
+				{
+					TaskID:         ptr.To("deploy-infrastructure"),
+					Codes:          []gardenv1beta1.ErrorCode{gardenv1beta1.ErrorInfraQuotaExceeded},
+					LastUpdateTime: &lastUpdateTime,
+				},
+				{
+					TaskID:         ptr.To("deploy-worker"),
+					Codes:          []gardenv1beta1.ErrorCode{gardenv1beta1.ErrorInfraQuotaExceeded},
+					LastUpdateTime: &lastUpdateTime,
+				},
+			},
+		},
+	}
+
+	var (
+		descs       = getGardenMetricsDefinitions()
+		errorCounts = make(map[shootErrorKey]float64)
+		ch          = make(chan prometheus.Metric, 3)
+	)
+	generateShootLastErrorMetrics(shoot, "test", newErrorClassifier(nil), descs[metricGardenShootLastError], errorCounts, testScrapeFailures, ch)
+
+	if len(ch) != 2 {
+		t.Fatalf("expected a metric per last error, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenShootLastError], prometheus.GaugeValue, float64(lastUpdateTime.Unix()),
+		"test-shoot", "test", string(gardenv1beta1.ErrorInfraQuotaExceeded), names.ErrorClassUser, "deploy-infrastructure", "shoot--test--test-shoot",
+	)
+	assert(t, expected, <-ch)
+
+	assert(t, errorCounts, map[shootErrorKey]float64{
+		{code: string(gardenv1beta1.ErrorInfraQuotaExceeded), classification: names.ErrorClassUser, iaas: "aws", seed: "test-seed"}: 1,
+	})
+}
+
+func Test_generateShootLastErrorMetrics_deduplicatesErrors(t *testing.T) {
+	older := metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+	shoot := &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-shoot"},
+		Status: gardenv1beta1.ShootStatus{
+			LastErrors: []gardenv1beta1.LastError{
+				{Codes: []gardenv1beta1.ErrorCode{gardenv1beta1.ErrorInfraQuotaExceeded}, LastUpdateTime: &older},
+				{Codes: []gardenv1beta1.ErrorCode{gardenv1beta1.ErrorInfraQuotaExceeded}, LastUpdateTime: &newer},
+			},
+		},
+	}
+
+	var (
+		desc = getGardenMetricsDefinitions()[metricGardenShootLastError]
+		ch   = make(chan prometheus.Metric, 2)
+	)
+	generateShootLastErrorMetrics(shoot, "test", newErrorClassifier(nil), desc, make(map[shootErrorKey]float64), testScrapeFailures, ch)
+
+	if len(ch) != 1 {
+		t.Fatalf("expected a single metric for the errors with the same code, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(newer.Unix()),
+		"test-shoot", "test", string(gardenv1beta1.ErrorInfraQuotaExceeded), names.ErrorClassUser, "", "",
+	)
+	assert(t, expected, <-ch)
+}
+
+func Test_errorClassifier(t *testing.T) {
+	lastErrors := []gardenv1beta1.LastError{
+		{Codes: []gardenv1beta1.ErrorCode{gardenv1beta1.ErrorInfraRateLimitsExceeded}},
+		{Codes: []gardenv1beta1.ErrorCode{"ERR_UNKNOWN"}},
+	}
+
+	classifier := newErrorClassifier(nil)
+	assert(t, classifier.hasUserErrors(lastErrors), false)
+	assert(t, strings.Join(classifier.classes(lastErrors), ","), "infrastructure,unknown")
+
+	classifier = newErrorClassifier(map[string]string{string(gardenv1beta1.ErrorInfraRateLimitsExceeded): names.ErrorClassUser})
+	assert(t, classifier.hasUserErrors(lastErrors), true)
+	assert(t, strings.Join(classifier.classes(lastErrors), ","), "unknown,user")
+	assert(t, classifier.classify(gardenv1beta1.ErrorInfraQuotaExceeded), names.ErrorClassUser)
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+// operationStart is the observed start of a Shoot operation.
+type operationStart struct {
+	operation gardenv1beta1.LastOperationType
+	time      float64
+}
+
+// shootOperationMetrics records the Shoot operations from the updates of the Shoot informer. Unlike the
+// metrics of the snapshot, they are not derived from the current state of the Shoots, but from their changes.
+type shootOperationMetrics struct {
+	duration    *prometheus.HistogramVec
+	transitions *prometheus.CounterVec
+
+	// starts contains the operations which started while they were watched, by Shoot UID. The informer calls
+	// the handler sequentially, so it does not need to be locked.
+	starts map[types.UID]operationStart
+}
+
+func newShootOperationMetrics(constLabels prometheus.Labels) *shootOperationMetrics {
+	return &shootOperationMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        metricGardenShootOperationDuration,
+			Help:        "Duration in seconds of finished Shoot operations, which started while the exporter was running.",
+			Buckets:     []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200},
+			ConstLabels: constLabels,
+		}, []string{
+			"operation",
+			"state",
+			"iaas",
+			"seed",
+			"version",
+		}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        metricGardenShootOperationTransitions,
+			Help:        "Total count of state transitions of Shoot operations. The from_state is empty if a new operation starts.",
+			ConstLabels: constLabels,
+		}, []string{
+			"operation",
+			"from_state",
+			"to_state",
+			"iaas",
+			"seed",
+		}),
+		starts: make(map[types.UID]operationStart),
+	}
+}
+
+// eventHandler returns an informer event handler which records the Shoot operations.
+func (m *shootOperationMetrics) eventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldShoot, ok := oldObj.(*gardenv1beta1.Shoot)
+			if !ok {
+				return
+			}
+			newShoot, ok := newObj.(*gardenv1beta1.Shoot)
+			if !ok {
+				return
+			}
+			m.update(oldShoot, newShoot)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if shoot, ok := obj.(*gardenv1beta1.Shoot); ok {
+				delete(m.starts, shoot.UID)
+			}
+		},
+	}
+}
+
+// update counts the state transition of the operation, records its start and observes its duration once it finished.
+func (m *shootOperationMetrics) update(oldShoot, newShoot *gardenv1beta1.Shoot) {
+	oldOperation, newOperation := oldShoot.Status.LastOperation, newShoot.Status.LastOperation
+	if newOperation == nil {
+		return
+	}
+	if oldOperation != nil && oldOperation.Type == newOperation.Type && oldOperation.State == newOperation.State {
+		return
+	}
+
+	// A new operation starts if the operation type changes or the previous operation finished.
+	var fromState string
+	if oldOperation != nil && oldOperation.Type == newOperation.Type && !operationFinished(oldOperation.State) {
+		fromState = string(oldOperation.State)
+	}
+	m.transitions.WithLabelValues(
+		string(newOperation.Type),
+		fromState,
+		string(newOperation.State),
+		newShoot.Spec.Provider.Type,
+		ptr.Deref(newShoot.Spec.SeedName, ""),
+	).Inc()
+
+	// The operation starts if the Shoot leaves a final state or another operation begins.
+	if !operationFinished(newOperation.State) {
+		if oldOperation == nil || oldOperation.Type != newOperation.Type || operationFinished(oldOperation.State) {
+			m.starts[newShoot.UID] = operationStart{
+				operation: newOperation.Type,
+				time:      float64(newOperation.LastUpdateTime.Unix()),
+			}
+		}
+		return
+	}
+
+	start, ok := m.starts[newShoot.UID]
+	if !ok || start.operation != newOperation.Type {
+		return
+	}
+	delete(m.starts, newShoot.UID)
+
+	m.duration.WithLabelValues(
+		string(newOperation.Type),
+		string(newOperation.State),
+		newShoot.Spec.Provider.Type,
+		ptr.Deref(newShoot.Spec.SeedName, ""),
+		newShoot.Spec.Kubernetes.Version,
+	).Observe(max(float64(newOperation.LastUpdateTime.Unix())-start.time, 0))
+}
+
+// operationFinished reports whether the operation does not continue in the given state.
+func operationFinished(state gardenv1beta1.LastOperationState) bool {
+	switch state {
+	case gardenv1beta1.LastOperationStateSucceeded, gardenv1beta1.LastOperationStateFailed, gardenv1beta1.LastOperationStateAborted:
+		return true
+	}
+	return false
+}
+
+// describe sends the descs of the allowed metrics.
+func (m *shootOperationMetrics) describe(filter MetricFilter, ch chan<- *prometheus.Desc) {
+	if filter.allowed(metricGardenShootOperationDuration) {
+		m.duration.Describe(ch)
+	}
+	if filter.allowed(metricGardenShootOperationTransitions) {
+		m.transitions.Describe(ch)
+	}
+}
+
+// collect sends the allowed metrics.
+func (m *shootOperationMetrics) collect(filter MetricFilter, ch chan<- prometheus.Metric) {
+	if filter.allowed(metricGardenShootOperationDuration) {
+		m.duration.Collect(ch)
+	}
+	if filter.allowed(metricGardenShootOperationTransitions) {
+		m.transitions.Collect(ch)
+	}
+}
+
+// shootOperationMetricNames contains the names of the metrics recorded from Shoot updates.
+var shootOperationMetricNames = []string{
+	metricGardenShootOperationDuration,
+	metricGardenShootOperationTransitions,
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var testOperationStart = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newOperationShoot(operation gardenv1beta1.LastOperationType, state gardenv1beta1.LastOperationState, after time.Duration) *gardenv1beta1.Shoot {
+	return &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-shoot", UID: "test-uid"},
+		Spec: gardenv1beta1.ShootSpec{
+			Provider:   gardenv1beta1.Provider{Type: "aws"},
+			SeedName:   ptr.To("test-seed"),
+			Kubernetes: gardenv1beta1.Kubernetes{Version: "1.31.1"},
+		},
+		Status: gardenv1beta1.ShootStatus{
+			LastOperation: &gardenv1beta1.LastOperation{
+				Type:           operation,
+				State:          state,
+				LastUpdateTime: metav1.NewTime(testOperationStart.Add(after)),
+			},
+		},
+	}
+}
+
+func Test_shootOperationMetrics_observesFinishedOperations(t *testing.T) {
+	var (
+		m          = newShootOperationMetrics(nil)
+		succeeded  = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateSucceeded, 0)
+		processing = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateProcessing, 0)
+		failing    = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateError, 5*time.Minute)
+		finished   = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateSucceeded, 10*time.Minute)
+	)
+
+	m.update(succeeded, processing)
+	m.update(processing, failing)
+	m.update(failing, finished)
+
+	expected := `
+# HELP garden_shoot_operation_duration_seconds Duration in seconds of finished Shoot operations, which started while the exporter was running.
+# TYPE garden_shoot_operation_duration_seconds histogram
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="60"} 0
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="120"} 0
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="300"} 0
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="600"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="900"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="1200"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="1800"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="2700"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="3600"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="5400"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="7200"} 1
+garden_shoot_operation_duration_seconds_bucket{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1",le="+Inf"} 1
+garden_shoot_operation_duration_seconds_sum{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1"} 600
+garden_shoot_operation_duration_seconds_count{iaas="aws",operation="Reconcile",seed="test-seed",state="Succeeded",version="1.31.1"} 1
+`
+	if err := testutil.CollectAndCompare(m.duration, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_shootOperationMetrics_ignoresOperationsWithUnknownStart(t *testing.T) {
+	var (
+		m          = newShootOperationMetrics(nil)
+		processing = newOperationShoot(gardenv1beta1.LastOperationTypeCreate, gardenv1beta1.LastOperationStateProcessing, 0)
+		progressed = newOperationShoot(gardenv1beta1.LastOperationTypeCreate, gardenv1beta1.LastOperationStateProcessing, time.Minute)
+		finished   = newOperationShoot(gardenv1beta1.LastOperationTypeCreate, gardenv1beta1.LastOperationStateSucceeded, 2*time.Minute)
+	)
+	progressed.Status.LastOperation.Progress = 50
+
+	m.update(processing, progressed)
+	m.update(progressed, finished)
+
+	if count := testutil.CollectAndCount(m.duration); count != 0 {
+		t.Errorf("expected no observation for an operation which started before it was watched, got %d", count)
+	}
+}
+
+func Test_shootOperationMetrics_countsTransitions(t *testing.T) {
+	var (
+		m          = newShootOperationMetrics(nil)
+		created    = newOperationShoot(gardenv1beta1.LastOperationTypeCreate, gardenv1beta1.LastOperationStateSucceeded, 0)
+		processing = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateProcessing, 0)
+		failing    = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateError, time.Minute)
+		retrying   = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateProcessing, 2*time.Minute)
+		progressed = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateProcessing, 3*time.Minute)
+		succeeded  = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateSucceeded, 4*time.Minute)
+	)
+
+	m.update(created, processing)
+	m.update(processing, failing)
+	m.update(failing, retrying)
+	m.update(retrying, progressed)
+	m.update(progressed, succeeded)
+
+	expected := `
+# HELP garden_shoot_operation_transitions_total Total count of state transitions of Shoot operations. The from_state is empty if a new operation starts.
+# TYPE garden_shoot_operation_transitions_total counter
+garden_shoot_operation_transitions_total{from_state="",iaas="aws",operation="Reconcile",seed="test-seed",to_state="Processing"} 1
+garden_shoot_operation_transitions_total{from_state="Processing",iaas="aws",operation="Reconcile",seed="test-seed",to_state="Error"} 1
+garden_shoot_operation_transitions_total{from_state="Error",iaas="aws",operation="Reconcile",seed="test-seed",to_state="Processing"} 1
+garden_shoot_operation_transitions_total{from_state="Processing",iaas="aws",operation="Reconcile",seed="test-seed",to_state="Succeeded"} 1
+`
+	if err := testutil.CollectAndCompare(m.transitions, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_shootOperationMetrics_countsRepeatedOperationAsNewOperation(t *testing.T) {
+	var (
+		m          = newShootOperationMetrics(nil)
+		reconciled = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateSucceeded, 0)
+		processing = newOperationShoot(gardenv1beta1.LastOperationTypeReconcile, gardenv1beta1.LastOperationStateProcessing, time.Hour)
+	)
+
+	m.update(reconciled, processing)
+
+	expected := `
+# HELP garden_shoot_operation_transitions_total Total count of state transitions of Shoot operations. The from_state is empty if a new operation starts.
+# TYPE garden_shoot_operation_transitions_total counter
+garden_shoot_operation_transitions_total{from_state="",iaas="aws",operation="Reconcile",seed="test-seed",to_state="Processing"} 1
+`
+	if err := testutil.CollectAndCompare(m.transitions, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
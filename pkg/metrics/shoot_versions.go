@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Components of a Shoot whose versions are offered by the CloudProfile.
+const (
+	versionComponentKubernetes   = "kubernetes"
+	versionComponentMachineImage = "machine_image"
+)
+
+// shootDeprecatedVersionKey groups the Shoots which run a deprecated version of a component.
+type shootDeprecatedVersionKey struct {
+	project   string
+	component string
+}
+
+// generateShootVersionMetrics exposes the expiration of the Kubernetes and machine image versions the Shoot and its
+// worker pools run, according to the given CloudProfile spec. The Shoot is counted per component which runs a
+// deprecated version.
+func generateShootVersionMetrics(shoot *gardenv1beta1.Shoot, projectName string, spec *gardenv1beta1.CloudProfileSpec, desc *prometheus.Desc,
+	deprecatedCounts map[shootDeprecatedVersionKey]float64, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric) {
+	if spec == nil {
+		return
+	}
+
+	deprecated := make(map[string]bool)
+	emit := func(workerPool, component, image string, version gardenv1beta1.ExpirableVersion) {
+		classification := v1beta1helper.CurrentLifecycleClassification(version)
+		if classification == gardenv1beta1.ClassificationDeprecated {
+			deprecated[component] = true
+		}
+
+		expiration := versionExpiration(version)
+		if expiration == nil {
+			return
+		}
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			float64(expiration.Unix()),
+			[]string{
+				shoot.Name,
+				projectName,
+				workerPool,
+				component,
+				image,
+				version.Version,
+				string(classification),
+				shoot.Status.TechnicalID,
+			}...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": "shoots"}).Inc()
+			return
+		}
+		ch <- metric
+	}
+
+	if version, ok := findKubernetesVersion(spec, shoot.Spec.Kubernetes.Version); ok {
+		emit("", versionComponentKubernetes, "", version)
+	}
+
+	for _, worker := range shoot.Spec.Provider.Workers {
+		// Worker pools only have their own Kubernetes version if it differs from the one of the Shoot.
+		if worker.Kubernetes != nil && worker.Kubernetes.Version != nil && *worker.Kubernetes.Version != shoot.Spec.Kubernetes.Version {
+			if version, ok := findKubernetesVersion(spec, *worker.Kubernetes.Version); ok {
+				emit(worker.Name, versionComponentKubernetes, "", version)
+			}
+		}
+
+		if worker.Machine.Image == nil || worker.Machine.Image.Version == nil {
+			continue
+		}
+		if version, ok := v1beta1helper.FindMachineImageVersion(spec.MachineImages, worker.Machine.Image.Name, *worker.Machine.Image.Version); ok {
+			emit(worker.Name, versionComponentMachineImage, worker.Machine.Image.Name, version.ExpirableVersion)
+		}
+	}
+
+	for component := range deprecated {
+		deprecatedCounts[shootDeprecatedVersionKey{project: projectName, component: component}]++
+	}
+}
+
+// findKubernetesVersion returns the Kubernetes version of the CloudProfile spec.
+func findKubernetesVersion(spec *gardenv1beta1.CloudProfileSpec, version string) (gardenv1beta1.ExpirableVersion, bool) {
+	for _, v := range spec.Kubernetes.Versions {
+		if v.Version == version {
+			return v, true
+		}
+	}
+	return gardenv1beta1.ExpirableVersion{}, false
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_generateShootVersionMetrics(t *testing.T) {
+	expirationDate := metav1.NewTime(time.Now().Add(24 * time.Hour).Truncate(time.Second))
+	spec := &gardenv1beta1.CloudProfileSpec{
+		Kubernetes: gardenv1beta1.KubernetesSettings{
+			Versions: []gardenv1beta1.ExpirableVersion{
+				{Version: "1.31.1", Classification: ptr.To(gardenv1beta1.ClassificationDeprecated), ExpirationDate: &expirationDate},
+				{Version: "1.32.0"},
+			},
+		},
+		MachineImages: []gardenv1beta1.MachineImage{{
+			Name: "gardenlinux",
+			Versions: []gardenv1beta1.MachineImageVersion{
+				{ExpirableVersion: gardenv1beta1.ExpirableVersion{Version: "1592.1.0", Classification: ptr.To(gardenv1beta1.ClassificationDeprecated)}},
+			},
+		}},
+	}
+	shoot := &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-shoot"},
+		Spec: gardenv1beta1.ShootSpec{
+			Kubernetes: gardenv1beta1.Kubernetes{Version: "1.32.0"},
+			Provider: gardenv1beta1.Provider{
+				Workers: []gardenv1beta1.Worker{
+					{
+						Name:       "worker-a",
+						Kubernetes: &gardenv1beta1.WorkerKubernetes{Version: ptr.To("1.31.1")},
+						Machine:    gardenv1beta1.Machine{Image: &gardenv1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")}},
+					},
+					{
+						Name:    "worker-b",
+						Machine: gardenv1beta1.Machine{Image: &gardenv1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")}},
+					},
+				},
+			},
+		},
+		Status: gardenv1beta1.ShootStatus{TechnicalID: "shoot--test--test-shoot"},
+	}
+
+	var (
+		descs            = getGardenMetricsDefinitions()
+		deprecatedCounts = make(map[shootDeprecatedVersionKey]float64)
+		ch               = make(chan prometheus.Metric, 2)
+	)
+	generateShootVersionMetrics(shoot, "test", spec, descs[metricGardenShootVersionExpiration], deprecatedCounts, testScrapeFailures, ch)
+
+	if len(ch) != 1 {
+		t.Fatalf("expected a metric for the only expiring version, got %d", len(ch))
+	}
+	expected, _ := prometheus.NewConstMetric(descs[metricGardenShootVersionExpiration], prometheus.GaugeValue, float64(expirationDate.Unix()),
+		"test-shoot", "test", "worker-a", versionComponentKubernetes, "", "1.31.1", "deprecated", "shoot--test--test-shoot",
+	)
+	assert(t, expected, <-ch)
+	assert(t, deprecatedCounts, map[shootDeprecatedVersionKey]float64{
+		{project: "test", component: versionComponentKubernetes}:   1,
+		{project: "test", component: versionComponentMachineImage}: 1,
+	})
+
+	generateShootVersionMetrics(shoot, "test", nil, descs[metricGardenShootVersionExpiration], deprecatedCounts, testScrapeFailures, ch)
+	assert(t, len(ch), 0)
+}
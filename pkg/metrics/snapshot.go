@@ -0,0 +1,510 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/template"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+// snapshot holds the precomputed metrics of a set of collectors. Informer events invalidate the metrics of the
+// collectors which depend on the changed resource and a background loop recomputes them, so that a scrape only
+// needs to serialize the current state. The granularity is the collector: an event recomputes all metrics of the
+// dependent collectors. Collectors with an objectCollector are the exception, an event of one of their objects
+// only recomputes the series of this object.
+type snapshot struct {
+	collectors   []namedCollector
+	names        map[*prometheus.Desc]string
+	dependencies map[string][]namedCollector
+	options      CollectorOptions
+	landscape    string
+	logger       *logrus.Logger
+
+	// synced reports per resource whether its informer has synced. Resources without an entry count as synced.
+	synced map[string]cache.InformerSynced
+
+	// workers limits the number of collectors which run at the same time, including timed out ones.
+	workers chan struct{}
+
+	mu     sync.RWMutex
+	series map[string][]prometheus.Metric
+	// counts holds the number of series per metric family, by collector.
+	counts map[string]map[string]int
+	dirty  map[string]bool
+	// running holds the collectors which timed out but did not return yet. They are not started again until they return.
+	running map[string]<-chan struct{}
+	// families are the names of the metric families in the snapshot.
+	families sets.Set[string]
+
+	// objects holds the series of the collectors with an objectCollector, by collector and object UID.
+	objects map[string]map[types.UID]objectSeries
+	// changed holds the objects whose series are outdated, by collector and object UID. Deleted objects are nil.
+	changed map[string]map[types.UID]interface{}
+	// aggregated holds the sum of the aggregations of all objects, by collector.
+	aggregated map[string]aggregation
+	// objectCounts holds the number of series of all objects per metric family, by collector.
+	objectCounts map[string]map[string]int
+
+	trigger chan struct{}
+}
+
+func newSnapshot(collectors []namedCollector, names map[*prometheus.Desc]string, options CollectorOptions, landscape string, logger *logrus.Logger) *snapshot {
+	s := &snapshot{
+		collectors:   collectors,
+		names:        names,
+		dependencies: make(map[string][]namedCollector),
+		options:      options,
+		landscape:    landscape,
+		logger:       logger,
+		synced:       make(map[string]cache.InformerSynced),
+		workers:      make(chan struct{}, max(options.Workers, 1)),
+		running:      make(map[string]<-chan struct{}),
+		series:       make(map[string][]prometheus.Metric),
+		counts:       make(map[string]map[string]int),
+		families:     sets.New[string](),
+		dirty:        make(map[string]bool),
+		objects:      make(map[string]map[types.UID]objectSeries),
+		changed:      make(map[string]map[types.UID]interface{}),
+		aggregated:   make(map[string]aggregation),
+		objectCounts: make(map[string]map[string]int),
+		trigger:      make(chan struct{}, 1),
+	}
+	for _, collector := range collectors {
+		for _, resource := range collector.resources {
+			s.dependencies[resource] = append(s.dependencies[resource], collector)
+		}
+		s.dirty[collector.name] = true
+	}
+	s.notify()
+	return s
+}
+
+// eventHandler returns an informer event handler which invalidates the metrics of all collectors depending on the resource.
+func (s *snapshot) eventHandler(resource string) cache.ResourceEventHandler {
+	var (
+		addEvents    = InformerEvents.WithLabelValues(resource, "add", s.landscape)
+		updateEvents = InformerEvents.WithLabelValues(resource, "update", s.landscape)
+		deleteEvents = InformerEvents.WithLabelValues(resource, "delete", s.landscape)
+	)
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			addEvents.Inc()
+			s.invalidateObject(resource, obj, false, false)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			updateEvents.Inc()
+			if unchanged(oldObj, newObj) {
+				return
+			}
+			s.invalidateObject(resource, newObj, false, statusUpdate(oldObj, newObj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			deleteEvents.Inc()
+			s.invalidateObject(resource, obj, true, false)
+		},
+	}
+}
+
+// invalidate marks the metrics of all collectors depending on the resource as outdated.
+func (s *snapshot) invalidate(resource string) {
+	var names []string
+	for _, collector := range s.dependencies[resource] {
+		names = append(names, collector.name)
+	}
+	s.invalidateCollectors(names...)
+}
+
+// invalidateObject marks the metrics of all collectors depending on the resource as outdated after an event of the
+// object. Collectors with series per object of the resource only recompute the series of this object. Collectors
+// which only read the spec of the resource ignore updates of the status.
+func (s *snapshot) invalidateObject(resource string, obj interface{}, deleted, statusOnly bool) {
+	uid, hasUID := objectUID(obj)
+	current := obj
+	if deleted {
+		current = nil
+	}
+
+	s.mu.Lock()
+	for _, collector := range s.dependencies[resource] {
+		switch {
+		case statusOnly && collector.isSpecResource(resource):
+		case hasUID && collector.objects != nil && collector.resources[0] == resource:
+			if s.changed[collector.name] == nil {
+				s.changed[collector.name] = make(map[types.UID]interface{})
+			}
+			s.changed[collector.name][uid] = current
+		default:
+			s.dirty[collector.name] = true
+		}
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+// invalidateCollectors marks the metrics of the given collectors as outdated.
+func (s *snapshot) invalidateCollectors(names ...string) {
+	s.mu.Lock()
+	for _, name := range names {
+		s.dirty[name] = true
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+// resync invalidates the metrics of the collector in its resync interval until the context is cancelled.
+func (s *snapshot) resync(ctx context.Context, collector namedCollector) {
+	ticker := time.NewTicker(collector.resync)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.invalidateCollectors(collector.name)
+		}
+	}
+}
+
+func (s *snapshot) notify() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// heartbeatInterval is the interval in which the refresh loop reports a heartbeat while it waits for events.
+const heartbeatInterval = 30 * time.Second
+
+// run refreshes the outdated metrics until the context is cancelled. Two refreshes are at least
+// options.RefreshInterval apart, so that bursts of informer events are handled by a single refresh.
+// The loop reports heartbeats to the health tracker, so that a wedged loop fails the liveness probe.
+func (s *snapshot) run(ctx context.Context) {
+	for _, collector := range s.collectors {
+		if collector.resync > 0 {
+			go s.resync(ctx, collector)
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		if s.options.Health != nil {
+			s.options.Health.Heartbeat(s.landscape, "snapshot")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			continue
+		case <-s.trigger:
+		}
+
+		s.refresh()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.options.RefreshInterval):
+		}
+	}
+}
+
+// objectRefresh holds the objects of a collector with an objectCollector whose series are outdated.
+type objectRefresh struct {
+	collector namedCollector
+	// all is set if the series of all objects are outdated.
+	all     bool
+	changed map[types.UID]interface{}
+}
+
+// refresh recomputes the metrics of all outdated collectors. If a collector times out, its previous metrics
+// are kept and it is refreshed again in the next cycle. Collectors whose informers have not synced are skipped,
+// they are refreshed once the informers have synced.
+func (s *snapshot) refresh() {
+	s.mu.Lock()
+	var (
+		outdated []namedCollector
+		objects  []objectRefresh
+	)
+	for _, collector := range s.collectors {
+		if collector.objects != nil {
+			refresh := objectRefresh{collector: collector, all: s.dirty[collector.name], changed: s.changed[collector.name]}
+			if !refresh.all && len(refresh.changed) == 0 {
+				continue
+			}
+			delete(s.dirty, collector.name)
+			delete(s.changed, collector.name)
+			if !s.hasSynced(collector) {
+				s.logger.Infof("Skipping collector %s as its informers have not synced yet.", collector.name)
+				CollectorSkipped.WithLabelValues(collector.name, s.landscape).Set(1)
+				continue
+			}
+			CollectorSkipped.WithLabelValues(collector.name, s.landscape).Set(0)
+			objects = append(objects, refresh)
+			continue
+		}
+		if !s.dirty[collector.name] {
+			continue
+		}
+		if done, ok := s.running[collector.name]; ok {
+			select {
+			case <-done:
+				delete(s.running, collector.name)
+			default:
+				// The previous run of the collector still occupies a worker, it stays outdated.
+				continue
+			}
+		}
+		delete(s.dirty, collector.name)
+		if !s.hasSynced(collector) {
+			s.logger.Infof("Skipping collector %s as its informers have not synced yet.", collector.name)
+			CollectorSkipped.WithLabelValues(collector.name, s.landscape).Set(1)
+			continue
+		}
+		CollectorSkipped.WithLabelValues(collector.name, s.landscape).Set(0)
+		outdated = append(outdated, collector)
+	}
+	s.mu.Unlock()
+
+	if len(outdated) == 0 && len(objects) == 0 {
+		return
+	}
+	if len(outdated) > 0 {
+		s.refreshCollectors(outdated)
+	}
+	for _, refresh := range objects {
+		s.refreshObjects(refresh)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.countSeries()
+	if len(s.dirty) > 0 {
+		s.notify()
+	}
+}
+
+// refreshCollectors runs the given collectors and replaces their metrics, unless they timed out.
+func (s *snapshot) refreshCollectors(outdated []namedCollector) {
+	results := runCollectors(outdated, s.workers, s.options.Timeout, s.landscape, s.logger)
+	counts := make([]map[string]int, len(results))
+	for i, result := range results {
+		if !result.timedOut {
+			results[i].metrics, counts[i] = s.filter(result.metrics)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, collector := range outdated {
+		if results[i].timedOut {
+			s.dirty[collector.name] = true
+			s.running[collector.name] = results[i].done
+			// Refresh the collector again as soon as its previous run returned, also without further events.
+			go func(done <-chan struct{}) {
+				<-done
+				s.notify()
+			}(results[i].done)
+			continue
+		}
+		s.series[collector.name] = results[i].metrics
+		s.counts[collector.name] = counts[i]
+	}
+}
+
+// refreshObjects recomputes the series of the outdated objects of a collector with an objectCollector and
+// updates the aggregated metrics of the collector from the contributions of its objects.
+func (s *snapshot) refreshObjects(refresh objectRefresh) {
+	var (
+		name  = refresh.collector.name
+		start = time.Now()
+	)
+	collect, err := refresh.collector.objects.prepare()
+	if err != nil {
+		s.logger.Errorf("Collector %s failed to prepare its objects: %s", name, err.Error())
+		ScrapeFailures.WithLabelValues(name, s.landscape).Inc()
+		s.invalidateCollectors(name)
+		return
+	}
+
+	changed := refresh.changed
+	if refresh.all {
+		changed = make(map[types.UID]interface{})
+		for _, obj := range refresh.collector.objects.list() {
+			if uid, ok := objectUID(obj); ok {
+				changed[uid] = obj
+			}
+		}
+	}
+	series := make(map[types.UID]*objectSeries, len(changed))
+	for uid, obj := range changed {
+		if obj == nil {
+			series[uid] = nil
+			continue
+		}
+		objSeries := s.collectObject(name, collect, obj)
+		objSeries.metrics, objSeries.counts = s.filter(objSeries.metrics)
+		series[uid] = &objSeries
+	}
+	CollectionDuration.WithLabelValues(name, s.landscape).Observe(time.Since(start).Seconds())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if refresh.all || s.objects[name] == nil {
+		s.objects[name] = make(map[types.UID]objectSeries)
+		s.aggregated[name] = make(aggregation)
+		s.objectCounts[name] = make(map[string]int)
+	}
+	var (
+		objects    = s.objects[name]
+		aggregated = s.aggregated[name]
+		counts     = s.objectCounts[name]
+	)
+	for uid, newSeries := range series {
+		if oldSeries, ok := objects[uid]; ok {
+			aggregated.merge(oldSeries.aggregated, -1)
+			addCounts(counts, oldSeries.counts, -1)
+			delete(objects, uid)
+		}
+		if newSeries == nil {
+			continue
+		}
+		objects[uid] = *newSeries
+		aggregated.merge(newSeries.aggregated, 1)
+		addCounts(counts, newSeries.counts, 1)
+	}
+
+	metrics, aggregatedCounts := s.filter(aggregated.metrics(name, s.landscape))
+	s.series[name] = metrics
+	s.counts[name] = maps.Clone(counts)
+	addCounts(s.counts[name], aggregatedCounts, 1)
+}
+
+// collectObject computes the series of a single object. A panic of the collector drops the series of the object.
+func (s *snapshot) collectObject(name string, collect collectObjectFunc, obj interface{}) objectSeries {
+	var (
+		ch     = make(chan prometheus.Metric)
+		series = objectSeries{aggregated: make(aggregation)}
+	)
+	go func() {
+		defer close(ch)
+		defer func() {
+			if r := recover(); r != nil {
+				s.logger.Errorf("Collector %s panicked: %v", name, r)
+				ScrapeFailures.WithLabelValues(name, s.landscape).Inc()
+			}
+		}()
+		collect(obj, ch, series.aggregated)
+	}()
+	for metric := range ch {
+		series.metrics = append(series.metrics, metric)
+	}
+	return series
+}
+
+// addCounts adds the series counts of other multiplied by the factor to counts. Families without series are removed.
+func addCounts(counts, other map[string]int, factor int) {
+	for family, count := range other {
+		counts[family] += factor * count
+		if counts[family] == 0 {
+			delete(counts, family)
+		}
+	}
+}
+
+// countSeries updates the number of series per metric family in the snapshot from the counts of the collectors.
+// It must be called with the lock held.
+func (s *snapshot) countSeries() {
+	counts := make(map[string]int)
+	for _, collectorCounts := range s.counts {
+		for family, count := range collectorCounts {
+			counts[family] += count
+		}
+	}
+	for family := range s.families {
+		if _, ok := counts[family]; !ok {
+			MetricSeries.DeleteLabelValues(family, s.landscape)
+			s.families.Delete(family)
+		}
+	}
+	for family, count := range counts {
+		MetricSeries.WithLabelValues(family, s.landscape).Set(float64(count))
+		s.families.Insert(family)
+	}
+}
+
+// hasSynced reports whether the informers of all resources the collector depends on have synced.
+func (s *snapshot) hasSynced(collector namedCollector) bool {
+	for _, resource := range collector.resources {
+		if synced, ok := s.synced[resource]; ok && !synced() {
+			return false
+		}
+	}
+	return true
+}
+
+// filter drops the metrics which are not allowed by the metric filter and counts the remaining series per metric family.
+func (s *snapshot) filter(metrics []prometheus.Metric) ([]prometheus.Metric, map[string]int) {
+	counts := make(map[string]int)
+	metrics = slices.DeleteFunc(metrics, func(metric prometheus.Metric) bool {
+		family := s.family(metric)
+		if !s.options.Metrics.allowed(family) {
+			return true
+		}
+		counts[family]++
+		return false
+	})
+	return metrics, counts
+}
+
+// family returns the name of the metric family of the metric. Metrics of templates carry it, the names of the
+// other metrics are looked up by their desc.
+func (s *snapshot) family(metric prometheus.Metric) string {
+	if metric, ok := metric.(template.FamilyMetric); ok {
+		return metric.Family
+	}
+	return s.names[metric.Desc()]
+}
+
+// collect sends the current metrics of all collectors in the order of the collectors.
+func (s *snapshot) collect(ch chan<- prometheus.Metric) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, collector := range s.collectors {
+		for _, series := range s.objects[collector.name] {
+			for _, metric := range series.metrics {
+				ch <- metric
+			}
+		}
+		for _, metric := range s.series[collector.name] {
+			ch <- metric
+		}
+	}
+}
+
+// unchanged reports whether an update event carries the same object version, e.g. in case of a resync.
+func unchanged(oldObj, newObj interface{}) bool {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return false
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return false
+	}
+	return oldMeta.GetResourceVersion() == newMeta.GetResourceVersion()
+}
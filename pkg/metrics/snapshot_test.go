@@ -0,0 +1,347 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener-metrics-exporter/pkg/template"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newCountingCollector(name string, resources []string, runs *atomic.Int32) namedCollector {
+	desc := prometheus.NewDesc(name, "Test metric.", nil, nil)
+	return namedCollector{
+		name:      name,
+		resources: resources,
+		collect: func(ch chan<- prometheus.Metric) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(runs.Add(1)))
+		},
+	}
+}
+
+func collectSnapshot(s *snapshot) []prometheus.Metric {
+	ch := make(chan prometheus.Metric, 10)
+	s.collect(ch)
+	close(ch)
+
+	var metrics []prometheus.Metric
+	for metric := range ch {
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func Test_snapshot_refreshesOnlyInvalidatedCollectors(t *testing.T) {
+	var shootRuns, seedRuns atomic.Int32
+	s := newSnapshot([]namedCollector{
+		newCountingCollector("shoots", []string{resourceShoots}, &shootRuns),
+		newCountingCollector("seeds", []string{resourceSeeds, resourceShoots}, &seedRuns),
+	}, nil, CollectorOptions{Workers: 2, Timeout: time.Second}, "", logrus.New())
+
+	if metrics := collectSnapshot(s); len(metrics) != 0 {
+		t.Fatalf("expected an empty snapshot before the first refresh, got %d metrics", len(metrics))
+	}
+
+	s.refresh()
+	if shootRuns.Load() != 1 || seedRuns.Load() != 1 {
+		t.Fatalf("expected all collectors to run once, got shoots=%d seeds=%d", shootRuns.Load(), seedRuns.Load())
+	}
+	if metrics := collectSnapshot(s); len(metrics) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(metrics))
+	}
+
+	s.invalidate(resourceSeeds)
+	s.refresh()
+	if shootRuns.Load() != 1 || seedRuns.Load() != 2 {
+		t.Errorf("expected only the seeds collector to run again, got shoots=%d seeds=%d", shootRuns.Load(), seedRuns.Load())
+	}
+
+	s.refresh()
+	if shootRuns.Load() != 1 || seedRuns.Load() != 2 {
+		t.Errorf("expected no collector to run without invalidation, got shoots=%d seeds=%d", shootRuns.Load(), seedRuns.Load())
+	}
+}
+
+func Test_snapshot_ignoresResyncUpdates(t *testing.T) {
+	var shootRuns atomic.Int32
+	s := newSnapshot([]namedCollector{
+		newCountingCollector("shoots", []string{resourceShoots}, &shootRuns),
+	}, nil, CollectorOptions{Workers: 1, Timeout: time.Second}, "", logrus.New())
+	s.refresh()
+
+	obj := &metav1.ObjectMeta{ResourceVersion: "1"}
+	s.eventHandler(resourceShoots).OnUpdate(obj, obj)
+	s.refresh()
+
+	if shootRuns.Load() != 1 {
+		t.Errorf("expected the collector not to run for a resync, got %d runs", shootRuns.Load())
+	}
+}
+
+func Test_snapshot_skipsCollectorsWithUnsyncedInformers(t *testing.T) {
+	var shootRuns, seedRuns atomic.Int32
+	s := newSnapshot([]namedCollector{
+		newCountingCollector("shoots", []string{resourceShoots}, &shootRuns),
+		newCountingCollector("seeds", []string{resourceSeeds, resourceShoots}, &seedRuns),
+	}, nil, CollectorOptions{Workers: 2, Timeout: time.Second}, "", logrus.New())
+
+	var seedsSynced atomic.Bool
+	s.synced[resourceShoots] = func() bool { return true }
+	s.synced[resourceSeeds] = seedsSynced.Load
+
+	s.refresh()
+	if shootRuns.Load() != 1 || seedRuns.Load() != 0 {
+		t.Fatalf("expected only the shoots collector to run, got shoots=%d seeds=%d", shootRuns.Load(), seedRuns.Load())
+	}
+
+	seedsSynced.Store(true)
+	s.invalidate(resourceSeeds)
+	s.refresh()
+	if shootRuns.Load() != 1 || seedRuns.Load() != 1 {
+		t.Errorf("expected the seeds collector to run once its informers synced, got shoots=%d seeds=%d", shootRuns.Load(), seedRuns.Load())
+	}
+}
+
+func Test_snapshot_countsSeriesPerMetricFamily(t *testing.T) {
+	desc := prometheus.NewDesc("shoots", "Test metric.", nil, nil)
+	s := newSnapshot([]namedCollector{{
+		name:      "shoots",
+		resources: []string{resourceShoots},
+		collect: func(ch chan<- prometheus.Metric) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1)
+			ch <- template.FamilyMetric{Metric: prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 2), Family: "shoots_custom"}
+		},
+	}}, map[*prometheus.Desc]string{desc: "shoots"}, CollectorOptions{Workers: 1, Timeout: time.Second}, "series-test", logrus.New())
+	s.refresh()
+
+	if count := testutil.ToFloat64(MetricSeries.WithLabelValues("shoots", "series-test")); count != 1 {
+		t.Errorf("expected 1 series for the shoots metric family, got %v", count)
+	}
+	if count := testutil.ToFloat64(MetricSeries.WithLabelValues("shoots_custom", "series-test")); count != 1 {
+		t.Errorf("expected 1 series for the metric family of the template metric, got %v", count)
+	}
+
+	s.options.Metrics = MetricFilter{Deny: []string{"shoots"}}
+	s.invalidate(resourceShoots)
+	s.refresh()
+
+	if MetricSeries.DeleteLabelValues("shoots", "series-test") {
+		t.Error("expected no series count for the denied metric family")
+	}
+	MetricSeries.DeleteLabelValues("shoots_custom", "series-test")
+}
+
+func Test_snapshot_doesNotRestartRunningCollector(t *testing.T) {
+	var (
+		runs    atomic.Int32
+		release = make(chan struct{})
+		desc    = prometheus.NewDesc("blocking", "Test metric.", nil, nil)
+	)
+	s := newSnapshot([]namedCollector{{
+		name: "blocking",
+		collect: func(ch chan<- prometheus.Metric) {
+			runs.Add(1)
+			<-release
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1)
+		},
+	}}, nil, CollectorOptions{Workers: 2, Timeout: 20 * time.Millisecond}, "", logrus.New())
+
+	s.refresh()
+	s.refresh()
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("expected the timed out collector not to be started again while it runs, got %d runs", got)
+	}
+
+	close(release)
+	<-s.running["blocking"]
+	s.refresh()
+	if got := runs.Load(); got != 2 {
+		t.Errorf("expected the collector to be started again once it returned, got %d runs", got)
+	}
+}
+
+func Test_snapshot_resyncsTimeDependentCollectors(t *testing.T) {
+	var runs atomic.Int32
+	collector := newCountingCollector("cloudprofiles", []string{resourceCloudProfiles}, &runs)
+	collector.resync = 10 * time.Millisecond
+	s := newSnapshot([]namedCollector{collector}, nil, CollectorOptions{Workers: 1, Timeout: time.Second}, "", logrus.New())
+	s.refresh()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go s.resync(ctx, collector)
+	for {
+		s.mu.RLock()
+		dirty := s.dirty[collector.name]
+		s.mu.RUnlock()
+		if dirty {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatal("expected the collector to be invalidated by its resync")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	s.refresh()
+	if got := runs.Load(); got != 2 {
+		t.Errorf("expected the collector to run again after its resync, got %d runs", got)
+	}
+}
+
+func Test_snapshot_refreshesTimedOutCollectorOnceItReturned(t *testing.T) {
+	var (
+		runs    atomic.Int32
+		release = make(chan struct{})
+		desc    = prometheus.NewDesc("slow", "Test metric.", nil, nil)
+	)
+	s := newSnapshot([]namedCollector{{
+		name: "slow",
+		collect: func(ch chan<- prometheus.Metric) {
+			if runs.Add(1) == 1 {
+				<-release
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1)
+		},
+	}}, nil, CollectorOptions{Workers: 1, Timeout: 20 * time.Millisecond, RefreshInterval: time.Millisecond}, "", logrus.New())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go s.run(ctx)
+
+	for runs.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// Wait until the run timed out, so that the snapshot has no outdated collector left to run.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for len(collectSnapshot(s)) == 0 {
+		if ctx.Err() != nil {
+			t.Fatal("expected the collector to be refreshed once its timed out run returned")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := runs.Load(); got != 2 {
+		t.Errorf("expected the collector to run again once, got %d runs", got)
+	}
+}
+
+// newObjectTestCollector returns a collector exposing a series per object and the number of objects as aggregated
+// metric. It counts the computations per object name.
+func newObjectTestCollector(objects *[]interface{}, computations map[string]int) namedCollector {
+	var (
+		desc      = prometheus.NewDesc("objects", "Test metric.", []string{"name"}, nil)
+		totalDesc = prometheus.NewDesc("objects_total", "Test metric.", []string{"kind"}, nil)
+	)
+	return namedCollector{
+		name:      "objects",
+		resources: []string{resourceShoots, resourceSeeds},
+		objects: &objectCollector{
+			list: func() []interface{} { return *objects },
+			prepare: func() (collectObjectFunc, error) {
+				return func(obj interface{}, ch chan<- prometheus.Metric, aggregated aggregation) {
+					name := obj.(*metav1.ObjectMeta).Name
+					computations[name]++
+					ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, name)
+					aggregated.add(totalDesc, 1, "shoot")
+				}, nil
+			},
+			specResources: []string{resourceSeeds},
+		},
+	}
+}
+
+// snapshotValues returns the values of the metrics in the snapshot by their desc and label values.
+func snapshotValues(t *testing.T, s *snapshot) map[string]float64 {
+	values := make(map[string]float64)
+	for _, metric := range collectSnapshot(s) {
+		dtoMetric := &dto.Metric{}
+		if err := metric.Write(dtoMetric); err != nil {
+			t.Fatalf("could not write metric: %v", err)
+		}
+		key := metric.Desc().String()
+		for _, label := range dtoMetric.GetLabel() {
+			key += "," + label.GetValue()
+		}
+		values[key] = dtoMetric.GetGauge().GetValue()
+	}
+	return values
+}
+
+func Test_snapshot_refreshesOnlyChangedObjects(t *testing.T) {
+	var (
+		shootA       = &metav1.ObjectMeta{Name: "a", UID: "uid-a", ResourceVersion: "1"}
+		shootB       = &metav1.ObjectMeta{Name: "b", UID: "uid-b", ResourceVersion: "1"}
+		objects      = []interface{}{shootA, shootB}
+		computations = make(map[string]int)
+		collector    = newObjectTestCollector(&objects, computations)
+		s            = newSnapshot([]namedCollector{collector}, nil, CollectorOptions{Workers: 1, Timeout: time.Second}, "", logrus.New())
+	)
+	s.refresh()
+	assert(t, computations, map[string]int{"a": 1, "b": 1})
+	values := snapshotValues(t, s)
+	if len(values) != 3 {
+		t.Fatalf("expected a series per object and the aggregated series, got %v", values)
+	}
+
+	updatedA := &metav1.ObjectMeta{Name: "a", UID: "uid-a", ResourceVersion: "2"}
+	s.eventHandler(resourceShoots).OnUpdate(shootA, updatedA)
+	s.refresh()
+	assert(t, computations, map[string]int{"a": 2, "b": 1})
+
+	s.eventHandler(resourceShoots).OnDelete(cache.DeletedFinalStateUnknown{Key: "b", Obj: shootB})
+	s.refresh()
+	objects = []interface{}{updatedA}
+	values = snapshotValues(t, s)
+	if len(values) != 2 {
+		t.Fatalf("expected the series of the deleted object to be removed, got %v", values)
+	}
+	for key, value := range values {
+		if strings.Contains(key, "objects_total") && value != 1 {
+			t.Errorf("expected the aggregated series to count the remaining object, got %v", value)
+		}
+	}
+
+	seed := &metav1.ObjectMeta{Name: "seed", UID: "uid-seed", ResourceVersion: "1", Generation: 1}
+	s.eventHandler(resourceSeeds).OnUpdate(seed, &metav1.ObjectMeta{Name: "seed", UID: "uid-seed", ResourceVersion: "2", Generation: 1})
+	s.refresh()
+	assert(t, computations, map[string]int{"a": 2, "b": 1})
+
+	s.eventHandler(resourceSeeds).OnUpdate(seed, &metav1.ObjectMeta{Name: "seed", UID: "uid-seed", ResourceVersion: "3", Generation: 2})
+	s.refresh()
+	assert(t, computations, map[string]int{"a": 3, "b": 1})
+}
+
+func Benchmark_snapshot_refreshChangedObject(b *testing.B) {
+	var (
+		objects      []interface{}
+		computations = make(map[string]int)
+	)
+	for i := range 10000 {
+		objects = append(objects, &metav1.ObjectMeta{Name: strconv.Itoa(i), UID: types.UID(strconv.Itoa(i)), ResourceVersion: "1"})
+	}
+	s := newSnapshot([]namedCollector{newObjectTestCollector(&objects, computations)}, nil, CollectorOptions{Workers: 1}, "", logrus.New())
+	s.refresh()
+
+	handler := s.eventHandler(resourceShoots)
+	b.ResetTimer()
+	for i := range b.N {
+		handler.OnUpdate(objects[0], &metav1.ObjectMeta{Name: "0", UID: "0", ResourceVersion: strconv.Itoa(i + 2)})
+		s.refresh()
+	}
+}
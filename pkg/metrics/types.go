@@ -1,35 +1,103 @@
 package metrics
 
+// Kinds of Garden resources which are watched by the metrics collectors.
+const (
+	resourceShoots                  = "shoots"
+	resourceSeeds                   = "seeds"
+	resourceProjects                = "projects"
+	resourceManagedSeeds            = "managedseeds"
+	resourceGardenlets              = "gardenlets"
+	resourceSecretBindings          = "secretbindings"
+	resourceCredentialsBindings     = "credentialsbindings"
+	resourceCloudProfiles           = "cloudprofiles"
+	resourceNamespacedCloudProfiles = "namespacedcloudprofiles"
+	resourceBackupBuckets           = "backupbuckets"
+	resourceBackupEntries           = "backupentries"
+	resourceControllerRegistrations = "controllerregistrations"
+	resourceControllerInstallations = "controllerinstallations"
+)
+
 const (
 	metricGardenProjectsStatus = "garden_projects_status"
 	metricGardenUsersSum       = "garden_users_total"
 
 	// Seed metric
-	metricGardenManagedSeedInfo    = "garden_managed_seed_info"
-	metricGardenSeedInfo           = "garden_seed_info"
-	metricGardenSeedCondition      = "garden_seed_condition"
-	metricGardenSeedCapacity       = "garden_seed_capacity"
-	metricGardenSeedUsage          = "garden_seed_usage"
-	metricGardenSeedOperationState = "garden_seed_operation_states"
+	metricGardenManagedSeedInfo             = "garden_managed_seed_info"
+	metricGardenSeedInfo                    = "garden_seed_info"
+	metricGardenSeedCondition               = "garden_seed_condition"
+	metricGardenSeedCapacity                = "garden_seed_capacity"
+	metricGardenSeedUsage                   = "garden_seed_usage"
+	metricGardenSeedAllocatable             = "garden_seed_allocatable"
+	metricGardenSeedHeadroom                = "garden_seed_headroom"
+	metricGardenSeedShootUsage              = "garden_seed_shoot_usage"
+	metricGardenSeedTaint                   = "garden_seed_taint_info"
+	metricGardenSeedSetting                 = "garden_seed_setting_info"
+	metricGardenSeedZone                    = "garden_seed_zone_info"
+	metricGardenSeedBackupInfo              = "garden_seed_backup_info"
+	metricGardenSeedIngressInfo             = "garden_seed_ingress_info"
+	metricGardenSeedDNSInfo                 = "garden_seed_dns_info"
+	metricGardenSeedGardenerVersionInfo     = "garden_seed_gardener_version_info"
+	metricGardenSeedGardenerVersionSkew     = "garden_seed_gardener_version_skew"
+	metricGardenSeedOperationState          = "garden_seed_operation_states"
+	metricGardenSeedConditionLastTransition = "garden_seed_condition_last_transition_timestamp_seconds"
 
 	// Gardenlet metric
-	metricGardenGardenletCondition          = "garden_gardenlet_condition"
-	metricGardenGardenletGeneration         = "garden_gardenlet_generation_total"
-	metricGardenGardenletObservedGeneration = "garden_gardenlet_observed_generation_total"
+	metricGardenGardenletCondition               = "garden_gardenlet_condition"
+	metricGardenGardenletGeneration              = "garden_gardenlet_generation_total"
+	metricGardenGardenletObservedGeneration      = "garden_gardenlet_observed_generation_total"
+	metricGardenGardenletConditionLastTransition = "garden_gardenlet_condition_last_transition_timestamp_seconds"
+	metricGardenGardenletInfo                    = "garden_gardenlet_info"
+	metricGardenGardenletSeedExists              = "garden_gardenlet_seed_exists"
+	metricGardenGardenletRolloutPending          = "garden_gardenlet_rollout_pending"
+	metricGardenGardenletRolloutPendingSince     = "garden_gardenlet_rollout_pending_since_timestamp_seconds"
+
+	// Backup metric
+	metricGardenBackupBucketInfo           = "garden_backupbucket_info"
+	metricGardenBackupBucketOperationState = "garden_backupbucket_operation_states"
+	metricGardenBackupBucketLastError      = "garden_backupbucket_last_error"
+	metricGardenBackupEntryInfo            = "garden_backupentry_info"
+	metricGardenBackupEntryOperationState  = "garden_backupentry_operation_states"
+	metricGardenBackupEntryLastError       = "garden_backupentry_last_error"
+	metricGardenBackupEntryOrphaned        = "garden_backupentry_orphaned"
+
+	// Controller metric
+	metricGardenControllerRegistrationResource  = "garden_controllerregistration_resource_info"
+	metricGardenControllerInstallationCondition = "garden_controllerinstallation_condition"
+
+	// CloudProfile metric
+	metricGardenCloudProfileKubernetesVersionInfo         = "garden_cloudprofile_kubernetes_version_info"
+	metricGardenCloudProfileKubernetesVersionExpiration   = "garden_cloudprofile_kubernetes_version_expiration_timestamp_seconds"
+	metricGardenCloudProfileMachineImageVersionInfo       = "garden_cloudprofile_machine_image_version_info"
+	metricGardenCloudProfileMachineImageVersionExpiration = "garden_cloudprofile_machine_image_version_expiration_timestamp_seconds"
+	metricGardenCloudProfileMachineTypeInfo               = "garden_cloudprofile_machine_type_info"
+	metricGardenCloudProfileMachineTypeCapacity           = "garden_cloudprofile_machine_type_capacity"
+	metricGardenCloudProfileVolumeTypeInfo                = "garden_cloudprofile_volume_type_info"
+	metricGardenCloudProfileRegionInfo                    = "garden_cloudprofile_region_info"
+
+	// NamespacedCloudProfile metric
+	metricGardenNamespacedCloudProfileInfo      = "garden_namespaced_cloudprofile_info"
+	metricGardenNamespacedCloudProfileOverrides = "garden_namespaced_cloudprofile_overrides"
 
 	// Shoot metric (available also for Shoots which act as Seed).
 	metricGardenShootCondition                = "garden_shoot_condition"
+	metricGardenShootConditionLastTransition  = "garden_shoot_condition_last_transition_timestamp_seconds"
 	metricGardenShootCreation                 = "garden_shoot_creation_timestamp"
 	metricGardenShootHibernated               = "garden_shoot_hibernated"
 	metricGardenShootInfo                     = "garden_shoot_info"
+	metricGardenShootLastError                = "garden_shoot_last_error"
 	metricGardenShootNodeMaxTotal             = "garden_shoot_node_max_total"
 	metricGardenShootNodeMinTotal             = "garden_shoot_node_min_total"
 	metricGardenShootOperationProgressPercent = "garden_shoot_operation_progress_percent"
 	metricGardenShootOperationState           = "garden_shoot_operation_states"
+	metricGardenShootOperationDuration        = "garden_shoot_operation_duration_seconds"
+	metricGardenShootOperationTransitions     = "garden_shoot_operation_transitions_total"
+	metricGardenShootVersionExpiration        = "garden_shoot_version_expiration_timestamp_seconds"
 	metricGardenShootWorkerNodeMaxTotal       = "garden_shoot_worker_node_max_total"
 	metricGardenShootWorkerNodeMinTotal       = "garden_shoot_worker_node_min_total"
 
 	// Aggregated Shoot metrics (exclude Shoots which act as Seed).
-	metricGardenOperationsTotal = "garden_shoot_operations_total"
-	metricGardenShootNodeInfo   = "garden_shoot_node_info"
+	metricGardenOperationsTotal              = "garden_shoot_operations_total"
+	metricGardenShootErrorsTotal             = "garden_shoot_errors_total"
+	metricGardenShootDeprecatedVersionsTotal = "garden_shoot_deprecated_versions_total"
+	metricGardenShootNodeInfo                = "garden_shoot_node_info"
 )
@@ -6,6 +6,8 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
+	"sync/atomic"
 
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
@@ -17,11 +19,78 @@ import (
 
 const unknown = "unknown"
 
-// ScrapeFailures is a metric, which counts the amount scrape issues grouped by kind.
+// ScrapeFailures is a metric, which counts the amount scrape issues grouped by kind and landscape.
 var ScrapeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Name: "garden_scrape_failure_total",
-	Help: "Total count of scraping failures, grouped by kind/group of metric(s)",
-}, []string{"kind"})
+	Help: "Total count of scraping failures, grouped by kind/group of metric(s) and landscape",
+}, []string{"kind", "landscape"})
+
+// InformerSynced is a metric, which indicates whether the informer of a resource has synced.
+var InformerSynced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "garden_exporter_informer_synced",
+	Help: "Whether the informer of a resource has synced. Possible values: 0=Syncing|1=Synced",
+}, []string{"resource", "landscape"})
+
+// InformerSyncDuration is a metric, which contains the duration until the informer of a resource has synced.
+var InformerSyncDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "garden_exporter_informer_sync_duration_seconds",
+	Help: "Duration in seconds until the informer of a resource has synced",
+}, []string{"resource", "landscape"})
+
+// CollectorSkipped is a metric, which indicates whether a collector is skipped because its informers have not synced.
+var CollectorSkipped = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "garden_exporter_collector_skipped",
+	Help: "Whether a collector is skipped because the informers it depends on have not synced. Possible values: 0=Running|1=Skipped",
+}, []string{"collector", "landscape"})
+
+// CollectionDuration is a metric, which observes the duration of the collectors.
+var CollectionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "garden_exporter_collection_duration_seconds",
+	Help:    "Duration in seconds of a single run of a collector",
+	Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30},
+}, []string{"collector", "landscape"})
+
+// MetricSeries is a metric, which contains the number of series per exposed metric family.
+var MetricSeries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "garden_exporter_metric_series",
+	Help: "Number of series the exporter exposes per metric family",
+}, []string{"metric", "landscape"})
+
+// InformerEvents is a metric, which counts the informer events per resource and event type.
+var InformerEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "garden_exporter_informer_events_total",
+	Help: "Total count of informer events, grouped by resource, event type and landscape",
+}, []string{"resource", "event", "landscape"})
+
+// BuildInfo is a metric, which contains the version and build information of the exporter.
+var BuildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "garden_exporter_build_info",
+	Help: "Version and build information of the exporter. The value is always 1",
+}, []string{"version", "git_commit", "build_date", "go_version"})
+
+// Leader is a metric, which indicates whether this exporter replica is the leader and serves the Garden metrics.
+var Leader = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "garden_exporter_leader",
+	Help: "Whether this exporter replica is the leader and serves the Garden metrics. Possible values: 0=Follower|1=Leader",
+})
+
+// leading is true if this exporter replica serves the Garden metrics. Without leader election every replica leads.
+var leading atomic.Bool
+
+func init() {
+	SetLeading(true)
+}
+
+// SetLeading sets whether this exporter replica is the leader. Followers keep their informers and the metrics
+// snapshot up to date, but do not serve the Garden metrics.
+func SetLeading(isLeader bool) {
+	leading.Store(isLeader)
+	if isLeader {
+		Leader.Set(1)
+	} else {
+		Leader.Set(0)
+	}
+}
 
 func mapConditionStatus(status gardenv1beta1.ConditionStatus) float64 {
 	switch status {
@@ -36,6 +105,51 @@ func mapConditionStatus(status gardenv1beta1.ConditionStatus) float64 {
 	}
 }
 
+// mapLastOperationState maps the state of an operation to the value of the operation state metrics.
+func mapLastOperationState(state gardenv1beta1.LastOperationState) float64 {
+	switch state {
+	case gardenv1beta1.LastOperationStateSucceeded:
+		return 1
+	case gardenv1beta1.LastOperationStateProcessing:
+		return 2
+	case gardenv1beta1.LastOperationStatePending:
+		return 3
+	case gardenv1beta1.LastOperationStateAborted:
+		return 4
+	case gardenv1beta1.LastOperationStateError:
+		return 5
+	case gardenv1beta1.LastOperationStateFailed:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// generateOperationStateMetrics exposes a metric per operation, whose value is the state of the last operation if
+// it is of this type and 0 otherwise. The operation is the last label of the desc.
+func generateOperationStateMetrics(lastOperation *gardenv1beta1.LastOperation, desc *prometheus.Desc, kind string, scrapeFailures *prometheus.CounterVec, ch chan<- prometheus.Metric, labelValues ...string) {
+	if lastOperation == nil {
+		return
+	}
+	for _, operation := range seedOperations {
+		var operationState float64
+		if operation == string(lastOperation.Type) {
+			operationState = mapLastOperationState(lastOperation.State)
+		}
+		metric, err := prometheus.NewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			operationState,
+			append(slices.Clone(labelValues), operation)...,
+		)
+		if err != nil {
+			scrapeFailures.With(prometheus.Labels{"kind": kind}).Inc()
+			continue
+		}
+		ch <- metric
+	}
+}
+
 func usedAsSeed(shoot *gardenv1beta1.Shoot, managedSeeds []*seedmanagementv1alpha1.ManagedSeed) bool {
 	if shoot.Namespace != constants.GardenNamespace {
 		return false
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// reviewCacheSize is the maximum number of cached TokenReview and SubjectAccessReview results each.
+	reviewCacheSize = 1024
+	// allowedTTL is the duration for which an authenticated token or an allowed request is cached.
+	allowedTTL = time.Minute
+	// deniedTTL is the duration for which an unauthenticated token or a denied request is cached. It is shorter than
+	// allowedTTL, so that granted permissions take effect quickly.
+	deniedTTL = 10 * time.Second
+)
+
+// authenticator protects a handler. A request is allowed if it presents a verified client certificate or a
+// bearer token which is authenticated by a TokenReview and authorized by a SubjectAccessReview to get the
+// request path, like kube-rbac-proxy does. The results of the reviews are cached for a short time, so that
+// regular scrapes do not create reviews each.
+type authenticator struct {
+	// clientCertificates allows requests with a verified client certificate.
+	clientCertificates bool
+	// client creates the TokenReviews and SubjectAccessReviews. Bearer tokens are not accepted if nil.
+	client kubernetes.Interface
+	logger *logrus.Logger
+
+	// tokens caches the authenticated users by the SHA-256 hash of the token, nil for unauthenticated tokens.
+	tokens *cache.LRUExpireCache
+	// decisions caches whether requests are allowed by their authorizationKey.
+	decisions *cache.LRUExpireCache
+}
+
+// authorizationKey identifies the attributes of a SubjectAccessReview.
+type authorizationKey struct {
+	user   string
+	uid    string
+	groups string
+	verb   string
+	path   string
+}
+
+func newAuthenticator(clientCertificates bool, client kubernetes.Interface, logger *logrus.Logger) *authenticator {
+	return &authenticator{
+		clientCertificates: clientCertificates,
+		client:             client,
+		logger:             logger,
+		tokens:             cache.NewLRUExpireCache(reviewCacheSize),
+		decisions:          cache.NewLRUExpireCache(reviewCacheSize),
+	}
+}
+
+func (a *authenticator) wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status := a.authorize(r); status != http.StatusOK {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// authorize returns the HTTP status of the request, http.StatusOK if it is allowed.
+func (a *authenticator) authorize(r *http.Request) int {
+	if a.clientCertificates && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return http.StatusOK
+	}
+	if a.client == nil {
+		return http.StatusUnauthorized
+	}
+
+	token, ok := bearerToken(r)
+	if !ok {
+		return http.StatusUnauthorized
+	}
+
+	user, err := a.authenticate(r.Context(), token)
+	if err != nil {
+		a.logger.Errorf("Could not create TokenReview: %s", err.Error())
+		return http.StatusInternalServerError
+	}
+	if user == nil {
+		return http.StatusUnauthorized
+	}
+
+	allowed, err := a.allowed(r.Context(), user, "get", r.URL.Path)
+	if err != nil {
+		a.logger.Errorf("Could not create SubjectAccessReview: %s", err.Error())
+		return http.StatusInternalServerError
+	}
+	if !allowed {
+		return http.StatusForbidden
+	}
+	return http.StatusOK
+}
+
+// authenticate returns the user of the token, nil if the token is not authenticated.
+func (a *authenticator) authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+	if user, ok := a.tokens.Get(key); ok {
+		return user.(*authenticationv1.UserInfo), nil
+	}
+
+	tokenReview, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !tokenReview.Status.Authenticated {
+		a.tokens.Add(key, (*authenticationv1.UserInfo)(nil), deniedTTL)
+		return nil, nil
+	}
+	user := &tokenReview.Status.User
+	a.tokens.Add(key, user, allowedTTL)
+	return user, nil
+}
+
+// allowed reports whether the user may use the verb on the non-resource path.
+func (a *authenticator) allowed(ctx context.Context, user *authenticationv1.UserInfo, verb, path string) (bool, error) {
+	key := authorizationKey{
+		user:   user.Username,
+		uid:    user.UID,
+		groups: strings.Join(user.Groups, ","),
+		verb:   verb,
+		path:   path,
+	}
+	if allowed, ok := a.decisions.Get(key); ok {
+		return allowed.(bool), nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	subjectAccessReview, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: path,
+				Verb: verb,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	allowed, ttl := subjectAccessReview.Status.Allowed, deniedTTL
+	if allowed {
+		ttl = allowedTTL
+	}
+	a.decisions.Add(key, allowed, ttl)
+	return allowed, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	return token, ok && token != ""
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeReviewClient returns a client which authenticates the token "valid" as user "prometheus" and
+// authorizes only this user.
+func newFakeReviewClient() *fake.Clientset {
+	client := fake.NewClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" || review.Spec.Token == "forbidden" {
+			review.Status.Authenticated = true
+			review.Status.User.Username = review.Spec.Token
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "valid" && review.Spec.NonResourceAttributes.Path == "/metrics"
+		return true, review, nil
+	})
+	return client
+}
+
+func TestAuthenticator_authorize(t *testing.T) {
+	cases := []struct {
+		name     string
+		auth     *authenticator
+		header   string
+		tls      *tls.ConnectionState
+		expected int
+	}{
+		{"no credentials", newAuthenticator(false, newFakeReviewClient(), logrus.New()), "", nil, http.StatusUnauthorized},
+		{"invalid token", newAuthenticator(false, newFakeReviewClient(), logrus.New()), "Bearer invalid", nil, http.StatusUnauthorized},
+		{"forbidden token", newAuthenticator(false, newFakeReviewClient(), logrus.New()), "Bearer forbidden", nil, http.StatusForbidden},
+		{"valid token", newAuthenticator(false, newFakeReviewClient(), logrus.New()), "Bearer valid", nil, http.StatusOK},
+		{"token without token authentication", newAuthenticator(true, nil, logrus.New()), "Bearer valid", nil, http.StatusUnauthorized},
+		{"unverified client certificate", newAuthenticator(true, nil, logrus.New()), "", &tls.ConnectionState{}, http.StatusUnauthorized},
+		{"verified client certificate", newAuthenticator(true, nil, logrus.New()), "", &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		request.TLS = tc.tls
+		if tc.header != "" {
+			request.Header.Set("Authorization", tc.header)
+		}
+		if got := tc.auth.authorize(request); got != tc.expected {
+			t.Errorf("%s: got status %d, want %d", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func TestAuthenticator_authorizeCachesReviews(t *testing.T) {
+	client := newFakeReviewClient()
+	auth := newAuthenticator(false, client, logrus.New())
+
+	for _, token := range []string{"valid", "valid", "forbidden", "forbidden"} {
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		auth.authorize(request)
+	}
+
+	var tokenReviews, subjectAccessReviews int
+	for _, action := range client.Actions() {
+		switch action.GetResource().Resource {
+		case "tokenreviews":
+			tokenReviews++
+		case "subjectaccessreviews":
+			subjectAccessReviews++
+		}
+	}
+	if tokenReviews != 2 || subjectAccessReviews != 2 {
+		t.Errorf("expected a single review per token, got %d TokenReviews and %d SubjectAccessReviews", tokenReviews, subjectAccessReviews)
+	}
+}
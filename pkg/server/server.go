@@ -12,6 +12,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 )
 
 var landingPage = []byte(`<html>
@@ -23,9 +24,34 @@ var landingPage = []byte(`<html>
 </html>
 `)
 
+// Options defines the configuration of the webserver.
+type Options struct {
+	// BindAddress is the IP address the webserver binds to.
+	BindAddress string
+	// Port is the port the webserver listens on.
+	Port int
+	// TLS enables TLS if set.
+	TLS *TLSOptions
+	// AuthClient enables the authentication of bearer tokens by TokenReviews and their authorization by
+	// SubjectAccessReviews for the metrics endpoint if set.
+	AuthClient kubernetes.Interface
+	// Readiness returns an error if the exporter is not ready to serve metrics. The exporter is always ready if nil.
+	Readiness func() error
+	// Liveness returns an error if the exporter is wedged and needs to be restarted. The exporter is always live if nil.
+	Liveness func() error
+}
+
 // Serve start the webserver and configure gracefull shut downs.
-func Serve(ctx context.Context, bindAddress string, port int, logger *logrus.Logger, stopCh chan struct{}) {
-	http.Handle("/metrics", promhttp.Handler())
+func Serve(ctx context.Context, options Options, logger *logrus.Logger, stopCh chan struct{}) {
+	metricsHandler := promhttp.Handler()
+	if options.AuthClient != nil || (options.TLS != nil && options.TLS.ClientCAFile != "") {
+		auth := newAuthenticator(options.TLS != nil && options.TLS.ClientCAFile != "", options.AuthClient, logger)
+		metricsHandler = auth.wrap(metricsHandler)
+	}
+
+	http.Handle("/metrics", metricsHandler)
+	http.HandleFunc("/healthz", checkHandler(options.Liveness, logger))
+	http.HandleFunc("/readyz", checkHandler(options.Readiness, logger))
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "Content-Type: text/html; charset=utf-8")
 		if _, err := w.Write(landingPage); err != nil {
@@ -34,10 +60,19 @@ func Serve(ctx context.Context, bindAddress string, port int, logger *logrus.Log
 	})
 
 	server := http.Server{
-		Addr:              fmt.Sprintf("%s:%d", bindAddress, port),
+		Addr:              fmt.Sprintf("%s:%d", options.BindAddress, options.Port),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
+	if options.TLS != nil {
+		reloader, err := newCertificateReloader(*options.TLS, logger)
+		if err != nil {
+			logger.Fatalf("Server starting error. %s", err.Error())
+		}
+		go reloader.run(ctx, certificateReloadInterval)
+		server.TLSConfig = reloader.tlsConfig()
+	}
+
 	go func() {
 		<-ctx.Done()
 		logger.Info("Shutting down webserver...")
@@ -55,8 +90,36 @@ func Serve(ctx context.Context, bindAddress string, port int, logger *logrus.Log
 		close(stopCh)
 	}()
 
-	logger.Infof("Starting webserver on port %d...", port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	logger.Infof("Starting webserver on port %d...", options.Port)
+	var err error
+	if options.TLS != nil {
+		// The certificates are provided by the TLS config.
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		logger.Fatalf("Server starting error. %s", err.Error())
 	}
 }
+
+// checkHandler returns a handler which reports the result of the check. A nil check always succeeds.
+func checkHandler(check func() error, logger *logrus.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if check != nil {
+			if err := check(); err != nil {
+				writeText(w, http.StatusServiceUnavailable, err.Error(), logger)
+				return
+			}
+		}
+		writeText(w, http.StatusOK, "ok", logger)
+	}
+}
+
+func writeText(w http.ResponseWriter, status int, text string, logger *logrus.Logger) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := fmt.Fprintln(w, text); err != nil {
+		logger.Warnf("Error writing HTTP response: %v", err)
+	}
+}
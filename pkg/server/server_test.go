@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func Test_checkHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		check  func() error
+		status int
+	}{
+		{name: "without check", status: http.StatusOK},
+		{name: "successful check", check: func() error { return nil }, status: http.StatusOK},
+		{name: "failed check", check: func() error { return errors.New("wedged") }, status: http.StatusServiceUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			checkHandler(tc.check, logrus.New())(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if recorder.Code != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, recorder.Code)
+			}
+		})
+	}
+}
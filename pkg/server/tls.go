@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// certificateReloadInterval is the interval in which the certificate files are checked for changes.
+const certificateReloadInterval = 10 * time.Second
+
+// TLSOptions defines the certificates of the webserver.
+type TLSOptions struct {
+	// CertFile is the path to the serving certificate.
+	CertFile string
+	// KeyFile is the path to the private key of the serving certificate.
+	KeyFile string
+	// ClientCAFile is the path to the CA bundle which verifies client certificates. If set, requests to the
+	// metrics endpoint are allowed with a verified client certificate.
+	ClientCAFile string
+}
+
+// certificateReloader keeps the serving certificate and the client CA bundle up to date with the files on disk.
+type certificateReloader struct {
+	options TLSOptions
+	logger  *logrus.Logger
+
+	modTimes map[string]time.Time
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+func newCertificateReloader(options TLSOptions, logger *logrus.Logger) (*certificateReloader, error) {
+	r := &certificateReloader{
+		options: options,
+		logger:  logger,
+	}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload loads the certificate files if one of them changed since the last load and reports whether it did.
+func (r *certificateReloader) reload() (bool, error) {
+	files := []string{r.options.CertFile, r.options.KeyFile}
+	if r.options.ClientCAFile != "" {
+		files = append(files, r.options.ClientCAFile)
+	}
+
+	modTimes := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	if maps.Equal(modTimes, r.modTimes) {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.options.CertFile, r.options.KeyFile)
+	if err != nil {
+		return false, fmt.Errorf("could not load serving certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.options.ClientCAFile != "" {
+		bundle, err := os.ReadFile(r.options.ClientCAFile) // #nosec G304: file path is a controlled launch parameter.
+		if err != nil {
+			return false, err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bundle) {
+			return false, fmt.Errorf("no certificates found in %s", r.options.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.mu.Unlock()
+	r.modTimes = modTimes
+	return true, nil
+}
+
+// run reloads the certificate files on change until the context is cancelled.
+func (r *certificateReloader) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := r.reload()
+		if err != nil {
+			r.logger.Errorf("Could not reload certificates: %s", err.Error())
+			continue
+		}
+		if reloaded {
+			r.logger.Info("Reloaded certificates.")
+		}
+	}
+}
+
+// tlsConfig returns a TLS configuration which always uses the latest certificates. Client certificates are
+// optional on the TLS level, so that e.g. probes can connect without one. They are enforced by the authenticator.
+func (r *certificateReloader) tlsConfig() *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The configuration for a client replaces the one of the server, which is only extended by the server
+		// to offer HTTP/2. Hence, the protocols are set explicitly.
+		NextProtos: []string{"h2", "http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.certificate, nil
+		},
+	}
+	if r.options.ClientCAFile == "" {
+		return config
+	}
+
+	base := config.Clone()
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		clientConfig := base.Clone()
+		clientConfig.ClientCAs = r.clientCAs
+		clientConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		return clientConfig, nil
+	}
+	return config
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// writeSelfSignedCertificate writes a self-signed certificate and its key to the directory and returns their paths.
+func writeSelfSignedCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gardener-metrics-exporter"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestCertificateReloader_tlsConfigServesHTTP2(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCertificate(t, t.TempDir())
+
+	for _, tc := range []struct {
+		name    string
+		options TLSOptions
+	}{
+		{"without client CA", TLSOptions{CertFile: certFile, KeyFile: keyFile}},
+		{"with client CA", TLSOptions{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reloader, err := newCertificateReloader(tc.options, logrus.New())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			server := &http.Server{
+				Handler:           http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+				TLSConfig:         reloader.tlsConfig(),
+				ReadHeaderTimeout: time.Second,
+			}
+			go func() { _ = server.ServeTLS(listener, "", "") }()
+			defer func() { _ = server.Close() }()
+
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, // #nosec G402: the test server uses a self-signed certificate.
+				ForceAttemptHTTP2: true,
+			}}
+			resp, err := client.Get("https://" + listener.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.ProtoMajor != 2 {
+				t.Errorf("expected the server to negotiate HTTP/2, got %s", resp.Proto)
+			}
+		})
+	}
+}
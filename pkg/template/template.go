@@ -28,6 +28,13 @@ const (
 	metricShootsPrefix       = "garden_shoots"
 )
 
+// FamilyMetric is a metric sample of a template together with the name of its metric family, which the
+// prometheus.Desc of the sample does not expose.
+type FamilyMetric struct {
+	prometheus.Metric
+	Family string
+}
+
 // MetricTemplate define a template for metrics of same kind. It holds all necessary
 // information about the metric and instructions how to collect metric samples.
 type MetricTemplate struct {
@@ -35,6 +42,7 @@ type MetricTemplate struct {
 	Help        string
 	Labels      []string
 	Type        Type
+	ConstLabels prometheus.Labels
 	desc        *prometheus.Desc
 	CollectFunc func(interface{}, ...interface{}) (*[]float64, *[][]string, error)
 }
@@ -42,13 +50,40 @@ type MetricTemplate struct {
 // Register registers the MetricTemplate to the Prometheus Gatherer to allow
 // the collection of metric samples which are created based on the template.
 func (m *MetricTemplate) Register(ch chan<- *prometheus.Desc) {
-	m.desc = prometheus.NewDesc(m.Name, m.Help, m.Labels, nil)
 	ch <- m.desc
 }
 
+// WithConstLabels returns a copy of the MetricTemplate whose metric samples carry the given constant labels.
+// Only such copies can be registered and collected, as their desc is created along with them.
+func (m *MetricTemplate) WithConstLabels(constLabels prometheus.Labels) *MetricTemplate {
+	return &MetricTemplate{
+		Name:        m.Name,
+		Help:        m.Help,
+		Labels:      m.Labels,
+		Type:        m.Type,
+		ConstLabels: constLabels,
+		desc:        prometheus.NewDesc(m.Name, m.Help, m.Labels, constLabels),
+		CollectFunc: m.CollectFunc,
+	}
+}
+
+// CollectOptions control which series are sent when metric samples are collected.
+type CollectOptions struct {
+	// Series sends the samples as series of the metric of the template.
+	Series bool
+	// Merged sends the samples of customization metrics also as series of the merged customization metric.
+	Merged bool
+}
+
 // Collect triggers the collection of metric samples which are created based on
 // template by executing the internal CollectFunc.
 func (m *MetricTemplate) Collect(ch chan<- prometheus.Metric, obj interface{}, parameters ...interface{}) {
+	m.CollectWithOptions(ch, CollectOptions{Series: true, Merged: true}, obj, parameters...)
+}
+
+// CollectWithOptions triggers the collection of metric samples like Collect, but only
+// sends the series selected by the options.
+func (m *MetricTemplate) CollectWithOptions(ch chan<- prometheus.Metric, options CollectOptions, obj interface{}, parameters ...interface{}) {
 	values, labelValues, err := m.CollectFunc(obj, parameters...)
 	if err != nil {
 		log.Error(err.Error())
@@ -67,6 +102,9 @@ func (m *MetricTemplate) Collect(ch chan<- prometheus.Metric, obj interface{}, p
 	}
 
 	for i := range vals {
+		if !options.Series {
+			break
+		}
 		var metric prometheus.Metric
 		if noLabels {
 			metric, err = prometheus.NewConstMetric(m.desc, mapType(m.Type), vals[i])
@@ -78,11 +116,11 @@ func (m *MetricTemplate) Collect(ch chan<- prometheus.Metric, obj interface{}, p
 			log.Error(err.Error())
 			return
 		}
-		ch <- metric
+		ch <- FamilyMetric{Metric: metric, Family: m.Name}
 	}
 
 	// build and send merged metric for customization metrics
-	if strings.Contains(m.Name, metricShootsCustomPrefix) {
+	if options.Merged && strings.Contains(m.Name, metricShootsCustomPrefix) {
 		m.sendMergedMetric(vals, labels, ch)
 	}
 }
@@ -100,6 +138,9 @@ func mapType(t Type) prometheus.ValueType {
 
 func (m *MetricTemplate) sendMergedMetric(vals []float64, labels [][]string, ch chan<- prometheus.Metric) {
 	l := make(map[string]string)
+	for name, value := range m.ConstLabels {
+		l[name] = value
+	}
 	l["customization"] = strings.Replace(m.Name, fmt.Sprintf("%s_", metricShootsCustomPrefix), "", 1)
 	var noLabels = len(labels) == 0
 
@@ -114,7 +155,7 @@ func (m *MetricTemplate) sendMergedMetric(vals []float64, labels [][]string, ch
 			log.Error(err.Error())
 			return
 		}
-		ch <- m
+		ch <- FamilyMetric{Metric: m, Family: metricShootsCustomPrefix}
 
 	} else {
 		for i := range vals {
@@ -125,7 +166,7 @@ func (m *MetricTemplate) sendMergedMetric(vals []float64, labels [][]string, ch
 				log.Error(err.Error())
 				continue
 			}
-			ch <- m
+			ch <- FamilyMetric{Metric: m, Family: metricShootsCustomPrefix}
 		}
 	}
 }
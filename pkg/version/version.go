@@ -19,18 +19,41 @@ var (
 	buildDate  = time.RFC3339
 )
 
+// Info contains the version and build information.
+type Info struct {
+	GitVersion string
+	GitCommit  string
+	BuildDate  string
+	GoVersion  string
+	Compiler   string
+	Platform   string
+}
+
+// Get returns the version and build information.
+func Get() Info {
+	return Info{
+		GitVersion: gitVersion,
+		GitCommit:  gitCommit,
+		BuildDate:  buildDate,
+		GoVersion:  runtime.Version(),
+		Compiler:   runtime.Compiler,
+		Platform:   fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+}
+
 // GetVersionCmd returns a pointer to a Cobra command, which expose version and build information.
 func GetVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Print version and build information",
 		Run: func(cmd *cobra.Command, args []string) {
+			info := Get()
 			fmt.Printf(`git version : %s
 git commit  : %s
 build date  : %s
 go version  : %s
 go compiler : %s
-platform    : %s/%s`, gitVersion, gitCommit, buildDate, runtime.Version(), runtime.Compiler, runtime.GOOS, runtime.GOARCH)
+platform    : %s`, info.GitVersion, info.GitCommit, info.BuildDate, info.GoVersion, info.Compiler, info.Platform)
 		},
 	}
 }